@@ -0,0 +1,281 @@
+package apply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/manifest"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// ApplyCommand contains data input from user for the apply command
+type ApplyCommand struct {
+	cmd *cobra.Command // cobra command instance
+
+	filenames      []string // files, directories or stdin to read manifests from
+	recursive      bool     // traverse directories recursively
+	forceConflicts bool     // take ownership of fields managed by others
+	run            bool     // start a BuildRun for each applied Build
+	follow         bool     // follow the logs of the BuildRun started
+
+	followOutput flags.FollowOutput // how the followed log lines are shown
+	gracePeriod  time.Duration      // time a pod may be unschedulable or failing to pull images
+
+	namespace        string            // namespace for objects without one
+	enforceNamespace bool              // namespace informed via --namespace, objects must match it
+	objects          []manifest.Object // objects read from the manifests
+	follower         *follower.Follower
+}
+
+const applyLongDesc = `
+Creates or updates Shipwright resources (Builds, BuildRuns, BuildStrategies and
+ClusterBuildStrategies) described on YAML or JSON manifests, using server-side apply. Manifests are
+validated against the Shipwright v1beta1 API before any change is made, build strategies also have
+their parameters and volumes checked. For example:
+
+	$ shp apply -f build.yaml
+	$ shp apply -f ./manifests --recursive
+	$ cat build.yaml | shp apply -f -
+
+Optionally, a BuildRun is started for the applied Build, following its logs:
+
+	$ shp apply -f build.yaml --run --follow
+`
+
+func applyCmd() runner.SubCommand {
+	c := &ApplyCommand{
+		cmd: &cobra.Command{
+			Use:   "apply -f <filename>",
+			Short: "Create or update Shipwright resources from manifests",
+			Long:  applyLongDesc,
+			Args:  cobra.NoArgs,
+			Annotations: map[string]string{
+				"commandType": "main",
+			},
+		},
+	}
+	c.cmd.Flags().StringSliceVarP(&c.filenames, "filename", "f", nil,
+		"file, directory or \"-\" for standard input, containing the resources to apply")
+	c.cmd.Flags().BoolVarP(&c.recursive, "recursive", "R", false,
+		"process the directories informed via --filename recursively")
+	c.cmd.Flags().BoolVar(&c.forceConflicts, "force-conflicts", false,
+		"take ownership of fields managed by other clients on conflicts")
+	c.cmd.Flags().BoolVar(&c.run, "run", false, "start a BuildRun for each applied Build")
+	flags.FollowFlag(c.cmd.Flags(), &c.follow)
+	flags.FollowOutputFlags(c.cmd.Flags(), &c.followOutput)
+	flags.PendingGracePeriodFlag(c.cmd.Flags(), &c.gracePeriod, follower.DefaultPendingGracePeriod)
+	return c
+}
+
+// Command represents "shp apply".
+func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Command {
+	return runner.NewRunner(p, ioStreams, applyCmd()).Cmd()
+}
+
+// Cmd returns cobra command object
+func (c *ApplyCommand) Cmd() *cobra.Command { return c.cmd }
+
+// Complete reads and decodes the informed manifests.
+func (c *ApplyCommand) Complete(p *params.Params, ioStreams *genericclioptions.IOStreams, _ []string) error {
+	if len(c.filenames) == 0 {
+		return errors.New("at least one file must be informed via --filename")
+	}
+	c.namespace = p.Namespace()
+	if f := c.cmd.Flag("namespace"); f != nil && f.Changed {
+		c.enforceNamespace = true
+	}
+
+	var err error
+	if c.objects, err = manifest.Read(c.filenames, c.recursive, ioStreams.In); err != nil {
+		return err
+	}
+	if err = manifest.Validate(c.objects); err != nil {
+		return err
+	}
+	for _, o := range c.objects {
+		if err = c.defaultNamespace(o); err != nil {
+			return err
+		}
+	}
+
+	if c.run && c.follow {
+		// the BuildRun name is only known after its creation
+		c.follower, err = p.NewFollower(c.cmd.Context(), types.NamespacedName{}, ioStreams)
+		if err != nil {
+			return err
+		}
+		c.follower.SetOutputOptions(c.followOutput.Prefix, !c.followOutput.NoColor)
+		c.follower.SetPendingGracePeriod(c.gracePeriod)
+	}
+	return nil
+}
+
+// Validate validates data input by user
+func (c *ApplyCommand) Validate() error {
+	if c.follow && !c.run {
+		return errors.New("--follow can only be used together with --run")
+	}
+	if c.follow && len(c.builds()) > 1 {
+		return errors.New("--follow can only be used when a single Build is applied")
+	}
+	return nil
+}
+
+// builds returns the Builds among the objects read.
+func (c *ApplyCommand) builds() []*buildv1beta1.Build {
+	var builds []*buildv1beta1.Build
+	for _, o := range c.objects {
+		if b, ok := o.Object.(*buildv1beta1.Build); ok {
+			builds = append(builds, b)
+		}
+	}
+	return builds
+}
+
+// Run applies the objects, in the order they were read, and optionally starts the Builds.
+func (c *ApplyCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	for _, o := range c.objects {
+		if err := c.apply(p, o); err != nil {
+			return fmt.Errorf("%s: applying %s %q: %w", o.Source, o.Kind(), o.Name(), err)
+		}
+		fmt.Fprintf(ioStreams.Out, "%s %q applied\n", o.Kind(), o.Name())
+	}
+
+	if !c.run {
+		return nil
+	}
+	for _, b := range c.builds() {
+		if err := c.runBuild(p, ioStreams, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply creates or updates the object using server-side apply, BuildRuns relying on generate-name
+// are created instead, since server-side apply requires a name.
+func (c *ApplyCommand) apply(p *params.Params, o manifest.Object) error {
+	ctx := c.cmd.Context()
+	clientset, err := p.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	opts := metav1.PatchOptions{FieldManager: manifest.FieldManager, Force: &c.forceConflicts}
+
+	switch obj := o.Object.(type) {
+	case *buildv1beta1.Build:
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = clientset.ShipwrightV1beta1().Builds(obj.Namespace).
+			Patch(ctx, obj.Name, types.ApplyPatchType, data, opts)
+		return err
+	case *buildv1beta1.BuildRun:
+		if obj.Name == "" {
+			created, err := clientset.ShipwrightV1beta1().BuildRuns(obj.Namespace).
+				Create(ctx, obj, metav1.CreateOptions{FieldManager: manifest.FieldManager})
+			if err == nil {
+				obj.Name = created.Name
+			}
+			return err
+		}
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = clientset.ShipwrightV1beta1().BuildRuns(obj.Namespace).
+			Patch(ctx, obj.Name, types.ApplyPatchType, data, opts)
+		return err
+	case *buildv1beta1.BuildStrategy:
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = clientset.ShipwrightV1beta1().BuildStrategies(obj.Namespace).
+			Patch(ctx, obj.Name, types.ApplyPatchType, data, opts)
+		return err
+	case *buildv1beta1.ClusterBuildStrategy:
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = clientset.ShipwrightV1beta1().ClusterBuildStrategies().
+			Patch(ctx, obj.Name, types.ApplyPatchType, data, opts)
+		return err
+	default:
+		return fmt.Errorf("unsupported kind %q", o.Kind())
+	}
+}
+
+// defaultNamespace sets the current namespace on namespaced objects without one. Like kubectl, when
+// the namespace is informed via --namespace, objects must not declare a different one.
+func (c *ApplyCommand) defaultNamespace(o manifest.Object) error {
+	var meta *metav1.ObjectMeta
+	switch obj := o.Object.(type) {
+	case *buildv1beta1.Build:
+		meta = &obj.ObjectMeta
+	case *buildv1beta1.BuildRun:
+		meta = &obj.ObjectMeta
+	case *buildv1beta1.BuildStrategy:
+		meta = &obj.ObjectMeta
+	default:
+		return nil
+	}
+
+	switch {
+	case meta.Namespace == "":
+		meta.Namespace = c.namespace
+	case c.enforceNamespace && meta.Namespace != c.namespace:
+		return fmt.Errorf("%s: the namespace from %s %q (%q) does not match the namespace %q, "+
+			"you must pass '--namespace=%s' to apply it", o.Source, o.Kind(), o.Name(),
+			meta.Namespace, c.namespace, meta.Namespace)
+	}
+	return nil
+}
+
+// runBuild creates a BuildRun for the informed Build, following its logs when requested.
+func (c *ApplyCommand) runBuild(p *params.Params, ioStreams *genericclioptions.IOStreams, b *buildv1beta1.Build) error {
+	clientset, err := p.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	br := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-", b.Name),
+		},
+		Spec: buildv1beta1.BuildRunSpec{
+			Build: buildv1beta1.ReferencedBuild{Name: &b.Name},
+		},
+	}
+	br, err = clientset.ShipwrightV1beta1().BuildRuns(b.Namespace).Create(c.cmd.Context(), br, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !c.follow {
+		fmt.Fprintf(ioStreams.Out, "BuildRun created %q for build %q\n", br.GetName(), b.Name)
+		return nil
+	}
+
+	c.follower.SetBuildRunName(types.NamespacedName{Namespace: b.Namespace, Name: br.GetName()})
+	_, err = c.follower.Start(metav1.ListOptions{LabelSelector: fmt.Sprintf(
+		"%s=%s,%s=%s",
+		buildv1beta1.LabelBuild,
+		b.Name,
+		buildv1beta1.LabelBuildRun,
+		br.GetName(),
+	)})
+	return err
+}
@@ -0,0 +1,163 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakekubetesting "k8s.io/client-go/testing"
+)
+
+const manifests = `
+apiVersion: shipwright.io/v1beta1
+kind: Build
+metadata:
+  name: sample-go
+spec:
+  source:
+    type: Git
+    git:
+      url: https://github.com/shipwright-io/sample-go
+  strategy:
+    kind: ClusterBuildStrategy
+    name: buildpacks-v3
+  output:
+    image: registry/sample-go:v2
+---
+apiVersion: shipwright.io/v1beta1
+kind: BuildRun
+metadata:
+  generateName: sample-go-
+spec:
+  build:
+    name: sample-go
+`
+
+func TestApply(t *testing.T) {
+	g := o.NewWithT(t)
+
+	filename := filepath.Join(t.TempDir(), "manifests.yaml")
+	g.Expect(os.WriteFile(filename, []byte(manifests), 0o600)).To(o.Succeed())
+
+	// the fake clientset only supports server-side apply on existing objects
+	existing := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-go", Namespace: "default"},
+		Spec:       buildv1beta1.BuildSpec{Output: buildv1beta1.Image{Image: "registry/sample-go:v1"}},
+	}
+	shpclient := shpfake.NewSimpleClientset(existing)
+	// the fake clientset doesn't honor generate-name, names are generated here instead
+	generated := 0
+	shpclient.PrependReactor("create", "buildruns", func(action fakekubetesting.Action) (bool, kruntime.Object, error) {
+		br := action.(fakekubetesting.CreateAction).GetObject().(*buildv1beta1.BuildRun)
+		if br.Name == "" {
+			generated++
+			br.Name = fmt.Sprintf("%s%d", br.GenerateName, generated)
+		}
+		return false, nil, nil
+	})
+	p := params.NewParamsForTest(nil, shpclient, nil, nil, "default", nil, nil)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	cmd := applyCmd().(*ApplyCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set("filename", filename)).To(o.Succeed())
+	g.Expect(cmd.Cmd().Flags().Set("run", "true")).To(o.Succeed())
+
+	g.Expect(cmd.Complete(p, &ioStreams, nil)).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.Succeed())
+	g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+	g.Expect(out.String()).To(o.ContainSubstring(`Build "sample-go" applied`))
+	g.Expect(out.String()).To(o.ContainSubstring(`BuildRun "sample-go-1" applied`))
+	g.Expect(out.String()).To(o.ContainSubstring(`BuildRun created "sample-go-2" for build "sample-go"`))
+
+	b, err := shpclient.ShipwrightV1beta1().Builds("default").Get(context.Background(), "sample-go", metav1.GetOptions{})
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(b.Spec.Output.Image).To(o.Equal("registry/sample-go:v2"))
+
+	brs, err := shpclient.ShipwrightV1beta1().BuildRuns("default").List(context.Background(), metav1.ListOptions{})
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(brs.Items).To(o.HaveLen(2))
+}
+
+func TestApplyValidate(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := applyCmd().(*ApplyCommand)
+	cmd.follow = true
+	g.Expect(cmd.Validate()).To(o.MatchError("--follow can only be used together with --run"))
+}
+
+func TestApplyNamespace(t *testing.T) {
+	const build = `
+apiVersion: shipwright.io/v1beta1
+kind: Build
+metadata:
+  name: sample-go
+  namespace: other
+spec:
+  strategy:
+    name: buildpacks-v3
+  output:
+    image: registry/sample-go
+`
+	tests := []struct {
+		name      string
+		namespace string // value informed via --namespace, if any
+		wantErr   string
+	}{{
+		name: "namespace from the manifest is kept",
+	}, {
+		name:      "matching --namespace",
+		namespace: "other",
+	}, {
+		name:      "different --namespace",
+		namespace: "default",
+		wantErr:   `the namespace from Build "sample-go" ("other") does not match the namespace "default"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			filename := filepath.Join(t.TempDir(), "build.yaml")
+			g.Expect(os.WriteFile(filename, []byte(build), 0o600)).To(o.Succeed())
+
+			ns := "default"
+			if tt.namespace != "" {
+				ns = tt.namespace
+			}
+			p := params.NewParamsForTest(nil, shpfake.NewSimpleClientset(), nil, nil, ns, nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+			// --namespace is a global flag, inherited from the root command
+			root := &cobra.Command{}
+			root.PersistentFlags().StringP("namespace", "n", "", "")
+			cmd := applyCmd().(*ApplyCommand)
+			root.AddCommand(cmd.Cmd())
+			g.Expect(cmd.Cmd().Flags().Set("filename", filename)).To(o.Succeed())
+			if tt.namespace != "" {
+				g.Expect(root.PersistentFlags().Set("namespace", tt.namespace)).To(o.Succeed())
+			}
+
+			err := cmd.Complete(p, &ioStreams, nil)
+			if tt.wantErr != "" {
+				g.Expect(err).To(o.MatchError(o.ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(o.HaveOccurred())
+			g.Expect(cmd.builds()[0].Namespace).To(o.Equal("other"))
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package apply contains types and functions for the apply cobra command
+package apply
@@ -25,6 +25,9 @@ func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Co
 	command.AddCommand(
 		runner.NewRunner(p, ioStreams, createCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, listCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, describeCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, exportCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, updateCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, deleteCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, runCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, uploadCmd()).Cmd(),
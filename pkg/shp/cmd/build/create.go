@@ -12,6 +12,8 @@ import (
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
 	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
+	shputil "github.com/shipwright-io/cli/pkg/shp/util"
 )
 
 // CreateCommand contains data input from user
@@ -23,12 +25,19 @@ type CreateCommand struct {
 	dockerfile   *string                 // For dockerfile parameter
 	builderImage *string                 // For builder image parameter
 
+	dryRun     flags.DryRunStrategy // dry-run strategy
+	printFlags *printer.PrintFlags  // output format of the created object
 }
 
 const buildCreateLongDesc = `
 Creates a new Build instance using the first argument as its name. For example:
 
 	$ shp build create my-app --source-url="..." --output-image="..."
+
+The Build manifest can be generated without creating it, or validated by the cluster without
+persisting it, using the dry-run flag alongside an output format:
+
+	$ shp build create my-app --source-url="..." --output-image="..." --dry-run=client -o yaml
 `
 
 // Cmd returns cobra.Command object of the create subcommand.
@@ -52,7 +61,10 @@ func (c *CreateCommand) Validate() error {
 	if c.name == "" {
 		return fmt.Errorf("name must be provided")
 	}
-	return nil
+	if c.printFlags.IsWide() {
+		return fmt.Errorf("output format %q is not supported", c.printFlags.OutputFormat)
+	}
+	return c.printFlags.Validate()
 }
 
 // Run executes the creation of a new Build instance using flags to fill up the details.
@@ -73,9 +85,14 @@ func (c *CreateCommand) Run(params *params.Params, ioStreams *genericclioptions.
 			b.Spec.Source.Type = buildv1beta1.GitType
 		}
 
-		// print warning with regards to source bundle image being used
+		// print warning with regards to source bundle image being used, on the error stream when the
+		// object itself is printed
+		warnOut := ioStreams.Out
+		if !c.printFlags.IsHumanReadable() {
+			warnOut = ioStreams.ErrOut
+		}
 		if b.Spec.Source.OCIArtifact != nil && b.Spec.Source.OCIArtifact.Image != "" {
-			fmt.Fprintf(ioStreams.Out, "Build %q uses a source bundle image, which means source code will be transferred to a container registry. It is advised to use private images to ensure the security of the source code being uploaded.\n", c.name)
+			fmt.Fprintf(warnOut, "Build %q uses a source bundle image, which means source code will be transferred to a container registry. It is advised to use private images to ensure the security of the source code being uploaded.\n", c.name)
 		}
 	}
 
@@ -99,14 +116,26 @@ func (c *CreateCommand) Run(params *params.Params, ioStreams *genericclioptions.
 		c.buildSpec.ParamValues = append(c.buildSpec.ParamValues, builderParam)
 	}
 
-	clientset, err := params.ShipwrightClientSet()
-	if err != nil {
-		return err
+	if c.dryRun != flags.DryRunClient {
+		clientset, err := params.ShipwrightClientSet()
+		if err != nil {
+			return err
+		}
+		err = shputil.ValidateVolumes(c.cmd.Context(), clientset, params.Namespace(), b.Spec.Strategy, b.Spec.Volumes)
+		if err != nil {
+			return err
+		}
+		b, err = clientset.ShipwrightV1beta1().Builds(params.Namespace()).Create(c.cmd.Context(), b, metav1.CreateOptions{
+			DryRun: c.dryRun.DryRun(),
+		})
+		if err != nil {
+			return err
+		}
 	}
-	if _, err := clientset.ShipwrightV1beta1().Builds(params.Namespace()).Create(c.cmd.Context(), b, metav1.CreateOptions{}); err != nil {
-		return err
+	if !c.printFlags.IsHumanReadable() {
+		return c.printFlags.Print(b, false, ioStreams.Out)
 	}
-	fmt.Fprintf(ioStreams.Out, "Created build %q\n", c.name)
+	fmt.Fprintf(ioStreams.Out, "Created build %q%s\n", c.name, c.dryRun.Suffix())
 	return nil
 }
 
@@ -125,10 +154,14 @@ func createCmd() runner.SubCommand {
 		panic(err)
 	}
 
-	return &CreateCommand{
+	c := &CreateCommand{
 		cmd:          cmd,
 		buildSpec:    buildSpecFlags,
 		dockerfile:   dockerfileFlag,
 		builderImage: builderImageFlag,
+		printFlags:   printer.NewPrintFlags(),
 	}
+	flags.DryRunFlags(cmd.Flags(), &c.dryRun)
+	c.printFlags.AddFlags(cmd)
+	return c
 }
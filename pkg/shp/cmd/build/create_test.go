@@ -0,0 +1,143 @@
+package build // nolint:revive
+
+import (
+	"context"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/ptr"
+)
+
+func TestCreateBuildDryRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		dryRun   string
+		output   string
+		expected []string
+	}{{
+		name:   "client with yaml output",
+		dryRun: "client",
+		output: "yaml",
+		expected: []string{
+			"apiVersion: shipwright.io/v1beta1",
+			"kind: Build",
+			"name: test-build",
+			"image: registry/app:latest",
+		},
+	}, {
+		name:     "client with default output",
+		dryRun:   "client",
+		expected: []string{"Created build \"test-build\" (dry run)"},
+	}, {
+		name:     "server with name output",
+		dryRun:   "server",
+		output:   "name",
+		expected: []string{"build.shipwright.io/test-build"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			shpclient := shpfake.NewSimpleClientset()
+			p := params.NewParamsForTest(nil, shpclient, nil, nil, "default", nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+			cmd := createCmd().(*CreateCommand)
+			cmd.Cmd().SetContext(context.Background())
+			fs := cmd.Cmd().Flags()
+			g.Expect(fs.Set(flags.OutputImageFlag, "registry/app:latest")).To(o.Succeed())
+			g.Expect(fs.Set(flags.DryRunFlag, tt.dryRun)).To(o.Succeed())
+			if tt.output != "" {
+				g.Expect(fs.Set("output", tt.output)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(p, &ioStreams, []string{"test-build"})).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+			for _, expected := range tt.expected {
+				g.Expect(out.String()).To(o.ContainSubstring(expected))
+			}
+
+			// the fake clientset doesn't honor server-side dry-run, only the client mode is asserted
+			if tt.dryRun == "client" {
+				builds, err := shpclient.ShipwrightV1beta1().Builds("default").List(context.Background(), metav1.ListOptions{})
+				g.Expect(err).ToNot(o.HaveOccurred())
+				g.Expect(builds.Items).To(o.BeEmpty())
+			}
+		})
+	}
+}
+
+func TestCreateBuildVolumes(t *testing.T) {
+	strategy := &buildv1beta1.BuildStrategy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "buildah"},
+		Spec: buildv1beta1.BuildStrategySpec{
+			Volumes: []buildv1beta1.BuildStrategyVolume{
+				{Name: "cache", Overridable: ptr.To(true)},
+				{Name: "settings", Overridable: ptr.To(true)},
+				{Name: "storage", Overridable: ptr.To(false)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		volume   string
+		err      string
+	}{{
+		name:     "overridable volume",
+		strategy: "buildah",
+		volume:   "cache=pvc:build-cache",
+	}, {
+		name:     "not overridable volume",
+		strategy: "buildah",
+		volume:   "storage=emptydir",
+		err:      `volume "storage" can't be overridden, valid volume names for BuildStrategy "buildah" are: cache, settings`,
+	}, {
+		name:     "strategy not found",
+		strategy: "kaniko",
+		volume:   "storage=emptydir",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			shpclient := shpfake.NewSimpleClientset(strategy)
+			p := params.NewParamsForTest(nil, shpclient, nil, nil, "default", nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+			cmd := createCmd().(*CreateCommand)
+			cmd.Cmd().SetContext(context.Background())
+			fs := cmd.Cmd().Flags()
+			g.Expect(fs.Set(flags.OutputImageFlag, "registry/app:latest")).To(o.Succeed())
+			g.Expect(fs.Set(flags.StrategyKindFlag, string(buildv1beta1.NamespacedBuildStrategyKind))).To(o.Succeed())
+			g.Expect(fs.Set(flags.StrategyNameFlag, tt.strategy)).To(o.Succeed())
+			g.Expect(fs.Set(flags.VolumeFlag, tt.volume)).To(o.Succeed())
+
+			g.Expect(cmd.Complete(p, &ioStreams, []string{"test-build"})).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			err := cmd.Run(p, &ioStreams)
+
+			builds, listErr := shpclient.ShipwrightV1beta1().Builds("default").List(context.Background(), metav1.ListOptions{})
+			g.Expect(listErr).ToNot(o.HaveOccurred())
+			if tt.err != "" {
+				g.Expect(err).To(o.MatchError(tt.err))
+				g.Expect(builds.Items).To(o.BeEmpty())
+				return
+			}
+			g.Expect(err).ToNot(o.HaveOccurred())
+			g.Expect(builds.Items).To(o.HaveLen(1))
+			g.Expect(builds.Items[0].Spec.Volumes).To(o.HaveLen(1))
+		})
+	}
+}
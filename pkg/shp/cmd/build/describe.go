@@ -0,0 +1,144 @@
+package build // nolint:revive
+
+import (
+	"fmt"
+	"sort"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/describe"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// recentBuildRunsLimit amount of BuildRuns shown on the Build description.
+const recentBuildRunsLimit = 5
+
+// DescribeCommand contains data provided by user to the describe subcommand
+type DescribeCommand struct {
+	cmd *cobra.Command
+
+	name string
+}
+
+const buildDescribeLongDesc = `
+Shows the details of a Build, including its effective configuration, registration status, the
+most recent BuildRuns and related events. For example:
+
+	$ shp build describe my-app
+`
+
+func describeCmd() runner.SubCommand {
+	return &DescribeCommand{
+		cmd: &cobra.Command{
+			Use:   "describe <name>",
+			Short: "Show details of a Build",
+			Long:  buildDescribeLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+}
+
+// Cmd returns cobra command object of the describe subcommand
+func (c *DescribeCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills DescribeCommand structure with data obtained from cobra command
+func (c *DescribeCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate is used for validation of user input data
+func (c *DescribeCommand) Validate() error {
+	return nil
+}
+
+// Run contains main logic of describe subcommand
+func (c *DescribeCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	ctx := c.cmd.Context()
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	k8sclient, err := params.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	b, err := clientset.ShipwrightV1beta1().Builds(params.Namespace()).Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	brList, err := clientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", buildv1beta1.LabelBuild, c.name),
+	})
+	if err != nil {
+		return err
+	}
+
+	events, err := describe.Events(ctx, k8sclient, params.Namespace(), describe.InvolvedObject{
+		Kind: "Build",
+		Name: b.GetName(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return describe.Tabbed(ioStreams.Out, func(w kdescribe.PrefixWriter) error {
+		describeBuild(w, b, brList.Items)
+		kdescribe.DescribeEvents(events, w)
+		return nil
+	})
+}
+
+// describeBuild writes the Build details, followed by the most recent BuildRuns.
+func describeBuild(w kdescribe.PrefixWriter, b *buildv1beta1.Build, buildRuns []buildv1beta1.BuildRun) {
+	describe.ObjectMeta(w, b.ObjectMeta)
+
+	w.Write(kdescribe.LEVEL_0, "Status:\n")
+	registered := string(corev1.ConditionUnknown)
+	if b.Status.Registered != nil {
+		registered = string(*b.Status.Registered)
+	}
+	reason := ""
+	if b.Status.Reason != nil {
+		reason = string(*b.Status.Reason)
+	}
+	w.Write(kdescribe.LEVEL_1, "Registered:\t%s\n", registered)
+	w.Write(kdescribe.LEVEL_1, "Reason:\t%s\n", describe.StringOrNone(&reason))
+	w.Write(kdescribe.LEVEL_1, "Message:\t%s\n", describe.StringOrNone(b.Status.Message))
+
+	w.Write(kdescribe.LEVEL_0, "Spec:\n")
+	describe.BuildSpec(w, kdescribe.LEVEL_1, &b.Spec)
+
+	if len(buildRuns) == 0 {
+		w.Write(kdescribe.LEVEL_0, "BuildRuns:\t<none>\n")
+		return
+	}
+	// most recent BuildRuns first
+	sort.Slice(buildRuns, func(i, j int) bool {
+		return buildRuns[j].CreationTimestamp.Before(&buildRuns[i].CreationTimestamp)
+	})
+	if len(buildRuns) > recentBuildRunsLimit {
+		buildRuns = buildRuns[:recentBuildRunsLimit]
+	}
+	w.Write(kdescribe.LEVEL_0, "BuildRuns:\n")
+	w.Write(kdescribe.LEVEL_1, "Name\tStatus\tCreated\n")
+	w.Write(kdescribe.LEVEL_1, "----\t------\t-------\n")
+	for _, br := range buildRuns {
+		status := string(metav1.ConditionUnknown)
+		if c := br.Status.GetCondition(buildv1beta1.Succeeded); c != nil && c.Reason != "" {
+			status = c.Reason
+		}
+		w.Write(kdescribe.LEVEL_1, "%s\t%s\t%s\n", br.Name, status, describe.Timestamp(&br.CreationTimestamp))
+	}
+}
@@ -0,0 +1,104 @@
+package build // nolint:revive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestDescribeBuild(t *testing.T) {
+	name := "test-build"
+	namespace := "default"
+
+	build := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: buildv1beta1.BuildSpec{
+			Strategy: buildv1beta1.Strategy{Name: "buildah", Kind: ptr.To(buildv1beta1.ClusterBuildStrategyKind)},
+			Source: &buildv1beta1.Source{
+				Type: buildv1beta1.GitType,
+				Git: &buildv1beta1.Git{
+					URL:      "https://github.com/shipwright-io/sample-go",
+					Revision: ptr.To("main"),
+				},
+				ContextDir: ptr.To("source-build"),
+			},
+			Output: buildv1beta1.Image{
+				Image:      "registry.example.com/test/image",
+				PushSecret: ptr.To("push-secret"),
+			},
+			ParamValues: []buildv1beta1.ParamValue{{
+				Name:        "dockerfile",
+				SingleValue: &buildv1beta1.SingleValue{Value: ptr.To("Containerfile")},
+			}, {
+				Name:   "build-args",
+				Values: []buildv1beta1.SingleValue{{Value: ptr.To("A=1")}, {Value: ptr.To("B=2")}},
+			}},
+		},
+		Status: buildv1beta1.BuildStatus{
+			Registered: ptr.To(corev1.ConditionTrue),
+			Reason:     ptr.To(buildv1beta1.SucceedStatus),
+			Message:    ptr.To("all validations succeeded"),
+		},
+	}
+	buildRun := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-build-xyz12",
+			Namespace: namespace,
+			Labels:    map[string]string{buildv1beta1.LabelBuild: name},
+		},
+		Status: buildv1beta1.BuildRunStatus{
+			Conditions: buildv1beta1.Conditions{{
+				Type:   buildv1beta1.Succeeded,
+				Status: corev1.ConditionTrue,
+				Reason: "Succeeded",
+			}},
+		},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "test-event", Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Build", Name: name},
+		Type:           corev1.EventTypeNormal,
+		Reason:         "Registered",
+		Message:        "build registered",
+	}
+
+	p := params.NewParamsForTest(fake.NewSimpleClientset(event), shpfake.NewSimpleClientset(build, buildRun), nil, nil, namespace, nil, nil)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	c := &cobra.Command{}
+	c.SetContext(context.Background())
+	cmd := &DescribeCommand{cmd: c, name: name}
+
+	if err := cmd.Run(p, &ioStreams); err != nil {
+		t.Fatalf("Describe.Run failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"Name:", name,
+		"Registered:", "True",
+		"Message:", "all validations succeeded",
+		"Strategy:", "ClusterBuildStrategy", "buildah",
+		"Source:", "Git",
+		"URL:", "https://github.com/shipwright-io/sample-go",
+		"Revision:", "main",
+		"Context Dir:", "source-build",
+		"Output:", "registry.example.com/test/image",
+		"Push Secret:", "push-secret",
+		"Param Values:", "dockerfile:", "Containerfile", "build-args:", "[A=1, B=2]",
+		"BuildRuns:", "test-build-xyz12", "Succeeded",
+		"Events:", "Registered", "build registered",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q on describe output:\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package build // nolint:revive
+
+import (
+	"fmt"
+	"sort"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/manifest"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// ExportCommand contains data provided by user to the export subcommand
+type ExportCommand struct {
+	cmd *cobra.Command
+
+	name   string
+	bundle bool // include the referenced strategy and secret references
+}
+
+const buildExportLongDesc = `
+Exports a Build as a portable YAML manifest, without status, namespace and attributes managed by
+the cluster, ready to be stored in git or applied on another cluster. For example:
+
+	$ shp build export my-app > my-app.yaml
+	$ shp apply -f my-app.yaml
+
+With --bundle, the namespaced BuildStrategy referenced by the Build is exported alongside it, and
+the ClusterBuildStrategy and secrets the Build depends on are listed as comments.
+`
+
+func exportCmd() runner.SubCommand {
+	c := &ExportCommand{
+		cmd: &cobra.Command{
+			Use:   "export <name>",
+			Short: "Export a Build as a portable manifest",
+			Long:  buildExportLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+	c.cmd.Flags().BoolVar(&c.bundle, "bundle", false,
+		"include the BuildStrategy and the references to the secrets required by the Build")
+	return c
+}
+
+// Cmd returns cobra command object of the export subcommand
+func (c *ExportCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills ExportCommand structure with data obtained from cobra command
+func (c *ExportCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate is used for validation of user input data
+func (c *ExportCommand) Validate() error {
+	return nil
+}
+
+// Run contains main logic of export subcommand
+func (c *ExportCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	ctx := c.cmd.Context()
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+
+	b, err := clientset.ShipwrightV1beta1().Builds(params.Namespace()).Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !c.bundle {
+		return manifest.WriteYAML(ioStreams.Out, nil, b)
+	}
+
+	objs := []runtime.Object{b}
+	var header []string
+	if b.Spec.Strategy.Kind != nil && *b.Spec.Strategy.Kind == buildv1beta1.ClusterBuildStrategyKind {
+		header = append(header, fmt.Sprintf("requires ClusterBuildStrategy %q", b.Spec.Strategy.Name))
+	} else {
+		bs, err := clientset.ShipwrightV1beta1().BuildStrategies(params.Namespace()).
+			Get(ctx, b.Spec.Strategy.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		objs = append(objs, bs)
+	}
+	for _, secret := range secretReferences(&b.Spec) {
+		header = append(header, fmt.Sprintf("requires Secret %q", secret))
+	}
+	return manifest.WriteYAML(ioStreams.Out, header, objs...)
+}
+
+// secretReferences returns the sorted names of the secrets referenced by the BuildSpec, for source
+// and output credentials, environment variables and parameter values.
+func secretReferences(spec *buildv1beta1.BuildSpec) []string {
+	names := map[string]bool{}
+	add := func(name *string) {
+		if name != nil && *name != "" {
+			names[*name] = true
+		}
+	}
+
+	if spec.Source != nil {
+		if spec.Source.Git != nil {
+			add(spec.Source.Git.CloneSecret)
+		}
+		if spec.Source.OCIArtifact != nil {
+			add(spec.Source.OCIArtifact.PullSecret)
+		}
+	}
+	add(spec.Output.PushSecret)
+	for _, env := range spec.Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			add(&env.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	for _, pv := range spec.ParamValues {
+		values := append([]buildv1beta1.SingleValue{}, pv.Values...)
+		if pv.SingleValue != nil {
+			values = append(values, *pv.SingleValue)
+		}
+		for _, v := range values {
+			if v.SecretValue != nil {
+				add(&v.SecretValue.Name)
+			}
+		}
+	}
+
+	secrets := make([]string, 0, len(names))
+	for name := range names {
+		secrets = append(secrets, name)
+	}
+	sort.Strings(secrets)
+	return secrets
+}
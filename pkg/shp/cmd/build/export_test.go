@@ -0,0 +1,78 @@
+package build // nolint:revive
+
+import (
+	"context"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/manifest"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/ptr"
+)
+
+func TestExportBuild(t *testing.T) {
+	g := o.NewWithT(t)
+
+	namespacedKind := buildv1beta1.NamespacedBuildStrategyKind
+	build := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-build",
+			Namespace:       "default",
+			UID:             "b2e9a3a4-uid",
+			ResourceVersion: "1234",
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "shp"}},
+		},
+		Spec: buildv1beta1.BuildSpec{
+			Source: &buildv1beta1.Source{
+				Type: buildv1beta1.GitType,
+				Git: &buildv1beta1.Git{
+					URL:         "https://github.com/shipwright-io/sample-go",
+					CloneSecret: ptr.To("git-credentials"),
+				},
+			},
+			Strategy: buildv1beta1.Strategy{Name: "buildah", Kind: &namespacedKind},
+			Output: buildv1beta1.Image{
+				Image:      "registry/app:latest",
+				PushSecret: ptr.To("registry-credentials"),
+			},
+			Env: []corev1.EnvVar{{
+				Name: "TOKEN",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "git-credentials"},
+					Key:                  "token",
+				}},
+			}},
+		},
+		Status: buildv1beta1.BuildStatus{Message: ptr.To("all validations succeeded")},
+	}
+	strategy := &buildv1beta1.BuildStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "buildah", Namespace: "default", UID: "c3f0-uid"},
+	}
+
+	p := params.NewParamsForTest(nil, shpfake.NewSimpleClientset(build, strategy), nil, nil, "default", nil, nil)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	cmd := exportCmd().(*ExportCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set("bundle", "true")).To(o.Succeed())
+	g.Expect(cmd.Complete(p, &ioStreams, []string{"test-build"})).To(o.Succeed())
+	g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+	g.Expect(out.String()).To(o.HavePrefix("# requires Secret \"git-credentials\"\n# requires Secret \"registry-credentials\"\n---\n"))
+	for _, unexpected := range []string{"uid:", "resourceVersion:", "managedFields:", "namespace:", "status:", "creationTimestamp:"} {
+		g.Expect(out.String()).ToNot(o.ContainSubstring(unexpected))
+	}
+
+	// the exported manifests must be valid input for the apply command
+	objects, err := manifest.Decode("export", out)
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(objects).To(o.HaveLen(2))
+	g.Expect(objects[0].Kind()).To(o.Equal("Build"))
+	g.Expect(objects[1].Kind()).To(o.Equal("BuildStrategy"))
+}
@@ -6,7 +6,9 @@ import (
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
 	"github.com/spf13/cobra"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors" // Import the k8serrors package
@@ -18,7 +20,9 @@ import (
 type ListCommand struct {
 	cmd *cobra.Command
 
-	noHeader bool
+	noHeader   bool
+	filter     flags.ListFilter
+	printFlags *printer.PrintFlags
 }
 
 func listCmd() runner.SubCommand {
@@ -27,9 +31,12 @@ func listCmd() runner.SubCommand {
 			Use:   "list [flags]",
 			Short: "List Builds",
 		},
+		printFlags: printer.NewPrintFlags(),
 	}
 
 	listCommand.cmd.Flags().BoolVar(&listCommand.noHeader, "no-header", false, "Do not show columns header in list output")
+	flags.ListFilterFlags(listCommand.cmd.Flags(), &listCommand.filter)
+	listCommand.printFlags.AddFlags(listCommand.cmd)
 
 	return listCommand
 }
@@ -46,14 +53,15 @@ func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams,
 
 // Validate checks user input data
 func (c *ListCommand) Validate() error {
-	return nil
+	// the table has no additional columns to show
+	if c.printFlags.IsWide() {
+		return fmt.Errorf("output format %q is not supported", c.printFlags.OutputFormat)
+	}
+	return c.printFlags.Validate()
 }
 
 // Run contains main logic of List subcommand of Build
 func (c *ListCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
-	// TODO: Support multiple output formats here, not only tabwriter
-	//       find out more in kubectl libraries and use them
-
 	// Initialize tabwriter for command output
 	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
 	columnNames := "NAME\tOUTPUT\tSTATUS"
@@ -69,23 +77,38 @@ func (c *ListCommand) Run(params *params.Params, ioStreams *genericclioptions.IO
 	if err != nil {
 		return fmt.Errorf("failed to get k8s client: %w", err)
 	}
-	_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), params.Namespace(), metav1.GetOptions{})
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			fmt.Fprintf(ioStreams.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", params.Namespace())
-			return nil
+	if !c.filter.AllNamespaces {
+		_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), params.Namespace(), metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				fmt.Fprintf(ioStreams.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", params.Namespace())
+				return nil
+			}
+			return err
 		}
-		return err
 	}
 
-	if buildList, err = clientset.ShipwrightV1beta1().Builds(params.Namespace()).List(c.cmd.Context(), metav1.ListOptions{}); err != nil {
+	namespace := c.filter.Namespace(params.Namespace())
+	if buildList, err = clientset.ShipwrightV1beta1().Builds(namespace).List(c.cmd.Context(), c.filter.ListOptions()); err != nil {
 		return err
 	}
+	if !c.printFlags.IsHumanReadable() {
+		return c.printFlags.Print(buildList, c.noHeader, ioStreams.Out)
+	}
 	if len(buildList.Items) == 0 {
-		fmt.Fprintf(ioStreams.Out, "No builds found in namespace '%s'. Please create a build or verify the namespace.\n", params.Namespace())
+		if c.filter.AllNamespaces {
+			fmt.Fprintln(ioStreams.Out, "No builds found in any namespace.")
+		} else {
+			fmt.Fprintf(ioStreams.Out, "No builds found in namespace '%s'. Please create a build or verify the namespace.\n", params.Namespace())
+		}
 		return nil
 	}
 
+	// the namespace column is shown when listing across namespaces
+	if c.filter.AllNamespaces {
+		columnNames = "NAMESPACE\t" + columnNames
+		columnTemplate = "%s\t" + columnTemplate
+	}
 	if !c.noHeader {
 		fmt.Fprintln(writer, columnNames)
 	}
@@ -95,7 +118,11 @@ func (c *ListCommand) Run(params *params.Params, ioStreams *genericclioptions.IO
 		if b.Status.Message != nil {
 			message = *b.Status.Message
 		}
-		fmt.Fprintf(writer, columnTemplate, b.Name, b.Spec.Output.Image, message)
+		values := []interface{}{b.Name, b.Spec.Output.Image, message}
+		if c.filter.AllNamespaces {
+			values = append([]interface{}{b.Namespace}, values...)
+		}
+		fmt.Fprintf(writer, columnTemplate, values...)
 	}
 
 	return writer.Flush()
@@ -0,0 +1,115 @@
+package build // nolint:revive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestListBuildsFilter(t *testing.T) {
+	newBuild := func(namespace, name, team string) *buildv1beta1.Build {
+		return &buildv1beta1.Build{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"team": team},
+		}}
+	}
+	kclient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b"}})
+
+	tests := []struct {
+		name          string
+		args          map[string]string
+		header        string
+		rows          []string
+		fieldSelector string
+	}{{
+		name:   "current namespace",
+		args:   map[string]string{},
+		header: "NAME",
+		rows:   []string{"build-b", "build-c"},
+	}, {
+		name:   "label selector",
+		args:   map[string]string{flags.SelectorFlag: "team=red"},
+		header: "NAME",
+		rows:   []string{"build-c"},
+	}, {
+		name:   "all namespaces",
+		args:   map[string]string{flags.AllNamespacesFlag: "true"},
+		header: "NAMESPACE",
+		rows:   []string{"tenant-a\tbuild-a", "tenant-b\tbuild-b", "tenant-b\tbuild-c"},
+	}, {
+		name:   "all namespaces with label selector",
+		args:   map[string]string{flags.AllNamespacesFlag: "true", flags.SelectorFlag: "team=blue"},
+		header: "NAMESPACE",
+		rows:   []string{"tenant-a\tbuild-a", "tenant-b\tbuild-b"},
+	}, {
+		// the fake clientset ignores field selectors, only the request is verified
+		name:          "field selector",
+		args:          map[string]string{flags.FieldSelectorFlag: "metadata.name=build-b"},
+		header:        "NAME",
+		rows:          []string{"build-b", "build-c"},
+		fieldSelector: "metadata.name=build-b",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			shpclient := shpfake.NewSimpleClientset(
+				newBuild("tenant-a", "build-a", "blue"),
+				newBuild("tenant-b", "build-b", "blue"),
+				newBuild("tenant-b", "build-c", "red"),
+			)
+			fieldSelector := ""
+			shpclient.PrependReactor("list", "builds", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				fieldSelector = action.(k8stesting.ListAction).GetListRestrictions().Fields.String()
+				return false, nil, nil
+			})
+
+			p := params.NewParamsForTest(kclient, shpclient, nil, nil, "tenant-b", nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := listCmd().(*ListCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(p, &ioStreams, nil)).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			g.Expect(lines).To(o.HaveLen(len(tt.rows) + 1))
+			g.Expect(lines[0]).To(o.HavePrefix(tt.header))
+			for i, row := range tt.rows {
+				g.Expect(lines[i+1]).To(o.HavePrefix(row))
+			}
+			g.Expect(fieldSelector).To(o.Equal(tt.fieldSelector))
+		})
+	}
+}
+
+func TestListBuildsValidate(t *testing.T) {
+	g := o.NewWithT(t)
+
+	cmd := listCmd().(*ListCommand)
+	g.Expect(cmd.Cmd().Flags().Set("output", "yaml")).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.Succeed())
+
+	// the table has no additional columns, wide would look the same as the default
+	g.Expect(cmd.Cmd().Flags().Set("output", "wide")).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.MatchError(`output format "wide" is not supported`))
+}
@@ -3,12 +3,16 @@ package build // nolint:revive
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/waiter"
 	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
+	shputil "github.com/shipwright-io/cli/pkg/shp/util"
 
 	"github.com/spf13/cobra"
 
@@ -28,6 +32,13 @@ type RunCommand struct {
 	follow        bool                       // flag to tail pod logs
 	follower      *follower.Follower
 	followerReady chan bool
+	followOutput  flags.FollowOutput // how the followed log lines are shown
+	gracePeriod   time.Duration      // time a pod may be unschedulable or failing to pull images
+	wait          bool               // flag to wait for the buildrun completion
+	waitTimeout   time.Duration      // maximum time waiting for the buildrun completion
+
+	dryRun     flags.DryRunStrategy // dry-run strategy
+	printFlags *printer.PrintFlags  // output format of the created object
 }
 
 const buildRunLongDesc = `
@@ -35,6 +46,16 @@ Creates a unique BuildRun instance for the given Build, which starts the build
 process orchestrated by the Shipwright build controller. For example:
 
 	$ shp build run my-app
+
+The command can block until the BuildRun is completed, without streaming its logs, exiting with
+non-zero status when the BuildRun fails:
+
+	$ shp build run my-app --wait --wait-timeout=30m
+
+The BuildRun manifest can be generated without creating it, or validated by the cluster without
+persisting it, using the dry-run flag alongside an output format:
+
+	$ shp build run my-app --dry-run=server -o yaml
 `
 
 // Cmd returns cobra.Command object of the create sub-command.
@@ -60,6 +81,8 @@ func (r *RunCommand) Complete(params *params.Params, ioStreams *genericclioption
 		if err != nil {
 			return err
 		}
+		r.follower.SetOutputOptions(r.followOutput.Prefix, !r.followOutput.NoColor)
+		r.follower.SetPendingGracePeriod(r.gracePeriod)
 		r.followerReady = make(chan bool, 1)
 	}
 	// overwriting build-ref name to use what's on arguments
@@ -71,7 +94,19 @@ func (r *RunCommand) Validate() error {
 	if r.buildName == "" {
 		return fmt.Errorf("name is not informed")
 	}
-	return nil
+	if r.follow && r.dryRun != flags.DryRunNone {
+		return errors.New("--follow cannot be used together with --dry-run")
+	}
+	if r.wait && r.follow {
+		return errors.New("--wait cannot be used together with --follow")
+	}
+	if r.wait && r.dryRun != flags.DryRunNone {
+		return errors.New("--wait cannot be used together with --dry-run")
+	}
+	if r.printFlags.IsWide() {
+		return fmt.Errorf("output format %q is not supported", r.printFlags.OutputFormat)
+	}
+	return r.printFlags.Validate()
 }
 
 // FollowerReady blocks until the any log following connections are established in the Run call.
@@ -95,18 +130,35 @@ func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOS
 	}
 	flags.SanitizeBuildRunSpec(&br.Spec)
 
+	if r.dryRun == flags.DryRunClient {
+		return r.print(ioStreams, br)
+	}
+
 	ctx := r.cmd.Context()
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
 	}
-	br, err = clientset.ShipwrightV1beta1().BuildRuns(r.namespace).Create(ctx, br, metav1.CreateOptions{})
+	if err = shputil.ValidateBuildRunVolumes(ctx, clientset, r.namespace, &br.Spec); err != nil {
+		return err
+	}
+	br, err = clientset.ShipwrightV1beta1().BuildRuns(r.namespace).Create(ctx, br, metav1.CreateOptions{
+		DryRun: r.dryRun.DryRun(),
+	})
 	if err != nil {
 		return err
 	}
 
 	if !r.follow {
-		fmt.Fprintf(ioStreams.Out, "BuildRun created %q for build %q\n", br.GetName(), r.buildName)
+		if err = r.print(ioStreams, br); err != nil || !r.wait {
+			return err
+		}
+		if _, err = waiter.Wait(ctx, clientset, r.namespace, br.GetName(), r.waitTimeout); err != nil {
+			return err
+		}
+		if r.printFlags.IsHumanReadable() {
+			fmt.Fprintf(ioStreams.Out, "BuildRun %q has succeeded\n", br.GetName())
+		}
 		return nil
 	}
 
@@ -130,6 +182,19 @@ func (r *RunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOS
 	return err
 }
 
+// print shows the BuildRun using the informed output format, or a short message by default.
+func (r *RunCommand) print(ioStreams *genericclioptions.IOStreams, br *buildv1beta1.BuildRun) error {
+	if !r.printFlags.IsHumanReadable() {
+		return r.printFlags.Print(br, false, ioStreams.Out)
+	}
+	name := br.GetName()
+	if name == "" {
+		name = br.GetGenerateName()
+	}
+	fmt.Fprintf(ioStreams.Out, "BuildRun created %q for build %q%s\n", name, r.buildName, r.dryRun.Suffix())
+	return nil
+}
+
 // runCmd instantiate the "build run" sub-command using common BuildRun flags.
 func runCmd() runner.SubCommand {
 	cmd := &cobra.Command{
@@ -140,7 +205,13 @@ func runCmd() runner.SubCommand {
 	runCommand := &RunCommand{
 		cmd:          cmd,
 		buildRunSpec: flags.BuildRunSpecFromFlags(cmd.Flags()),
+		printFlags:   printer.NewPrintFlags(),
 	}
 	flags.FollowFlag(cmd.Flags(), &runCommand.follow)
+	flags.FollowOutputFlags(cmd.Flags(), &runCommand.followOutput)
+	flags.PendingGracePeriodFlag(cmd.Flags(), &runCommand.gracePeriod, follower.DefaultPendingGracePeriod)
+	flags.WaitFlags(cmd.Flags(), &runCommand.wait, &runCommand.waitTimeout)
+	flags.DryRunFlags(cmd.Flags(), &runCommand.dryRun)
+	runCommand.printFlags.AddFlags(cmd)
 	return runCommand
 }
@@ -0,0 +1,411 @@
+package build // nolint:revive
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	shputil "github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+// UpdateCommand contains data input from user
+type UpdateCommand struct {
+	cmd *cobra.Command // cobra command instance
+
+	name         string                  // build resource's name
+	buildSpec    *buildv1beta1.BuildSpec // stores command-line flags
+	dockerfile   *string                 // For dockerfile parameter
+	builderImage *string                 // For builder image parameter
+
+	envRemove          []string // environment variable names to remove
+	paramValueRemove   []string // parameter names to remove
+	nodeSelectorRemove []string // node selector keys to remove
+}
+
+const buildUpdateLongDesc = `
+Updates an existing Build instance, only the attributes informed via command-line flags are
+changed, everything else is kept as is. Environment variables, parameter values and node selectors
+are merged with the existing entries, and can be removed by name. For example:
+
+	$ shp build update my-app --output-image="..." --param-value="key=value"
+	$ shp build update my-app --env-remove="KEY" --node-selector-remove="kubernetes.io/hostname"
+
+A diff of the Build specification is shown before the changes are applied.
+`
+
+// Cmd returns cobra.Command object of the update subcommand.
+func (c *UpdateCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills internal subcommand structure for future work with user input
+func (c *UpdateCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	switch len(args) {
+	case 1:
+		c.name = args[0]
+	default:
+		return fmt.Errorf("one argument is expected")
+	}
+	return nil
+}
+
+// Validate is used for user input validation of flags and other data.
+func (c *UpdateCommand) Validate() error {
+	if c.name == "" {
+		return fmt.Errorf("name must be provided")
+	}
+	// the inherited persistent flags, like the namespace, don't update the Build
+	changed := false
+	c.cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		changed = changed || f.Changed
+	})
+	if !changed {
+		return fmt.Errorf("at least one flag must be informed to update the Build")
+	}
+	return nil
+}
+
+// Run retrieves the existing Build, applies the changed flags and patches the instance.
+func (c *UpdateCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	builds := clientset.ShipwrightV1beta1().Builds(params.Namespace())
+
+	current, err := builds.Get(c.cmd.Context(), c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// only the flag values are sanitized, the Build retrieved from the cluster is kept as is, so the
+	// merge patch carries only the changed attributes. The source is merged field by field instead,
+	// sanitizing it would drop the git attributes informed without the repository URL
+	source := c.buildSpec.Source.DeepCopy()
+	flags.SanitizeBuildSpec(c.buildSpec)
+	c.buildSpec.Source = source
+	updated := current.DeepCopy()
+	if err = mergeBuildSpec(c.cmd.Flags(), &updated.Spec, c.buildSpec); err != nil {
+		return err
+	}
+	if c.cmd.Flags().Changed(flags.DockerfileFlag) {
+		upsertParamValue(&updated.Spec.ParamValues, "dockerfile", *c.dockerfile)
+	}
+	if c.cmd.Flags().Changed(flags.BuilderImageFlag) {
+		upsertParamValue(&updated.Spec.ParamValues, "builder-image", *c.builderImage)
+	}
+	removeBuildSpecEntries(&updated.Spec, c.envRemove, c.paramValueRemove, c.nodeSelectorRemove)
+	if c.cmd.Flags().Changed(flags.VolumeFlag) {
+		err = shputil.ValidateVolumes(c.cmd.Context(), clientset, params.Namespace(), updated.Spec.Strategy, updated.Spec.Volumes)
+		if err != nil {
+			return err
+		}
+	}
+
+	diff, err := shputil.YAMLDiff(c.name, current.Spec, updated.Spec)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		fmt.Fprintf(ioStreams.Out, "Build %q is unchanged\n", c.name)
+		return nil
+	}
+	fmt.Fprint(ioStreams.Out, diff)
+
+	patch, err := buildMergePatch(current, updated)
+	if err != nil {
+		return err
+	}
+	if _, err := builds.Patch(c.cmd.Context(), c.name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.Out, "Updated build %q\n", c.name)
+	return nil
+}
+
+// buildMergePatch creates a JSON merge patch to transform the current Build into the updated one.
+// The patch carries the current resource version, so the API server rejects it with a conflict
+// when the Build was modified after it was retrieved.
+func buildMergePatch(current, updated *buildv1beta1.Build) ([]byte, error) {
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	updatedJSON, err := json.Marshal(updated)
+	if err != nil {
+		return nil, err
+	}
+	patchJSON, err := jsonpatch.CreateMergePatch(currentJSON, updatedJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := map[string]interface{}{}
+	if err = json.Unmarshal(patchJSON, &patch); err != nil {
+		return nil, err
+	}
+	metadata, _ := patch["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["resourceVersion"] = current.ResourceVersion
+	patch["metadata"] = metadata
+	return json.Marshal(patch)
+}
+
+// mergeBuildSpec copies onto dst the attributes of src which have been explicitly informed on the
+// command-line, list and map attributes are merged with the existing entries. The src is sanitized,
+// thus empty inner structures may be nil, except for the source where empty values are cleared. The source type only changes when its location is
+// informed, the other source flags must match the current source type.
+func mergeBuildSpec(fs *pflag.FlagSet, dst, src *buildv1beta1.BuildSpec) error {
+	changed := func(names ...string) bool {
+		for _, name := range names {
+			if fs.Changed(name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	srcSource := &buildv1beta1.Source{}
+	if src.Source != nil {
+		srcSource = src.Source
+	}
+	srcGit := &buildv1beta1.Git{}
+	if srcSource.Git != nil {
+		srcGit = srcSource.Git
+	}
+	srcOCIArtifact := &buildv1beta1.OCIArtifact{}
+	if srcSource.OCIArtifact != nil {
+		srcOCIArtifact = srcSource.OCIArtifact
+	}
+	srcRetention := &buildv1beta1.BuildRetention{}
+	if src.Retention != nil {
+		srcRetention = src.Retention
+	}
+
+	// source, switching between git and OCI artifact when the location changes
+	if changed(flags.SourceGitRevisionFlag, flags.SourceRevisionFlag, flags.SourceGitCloneSecretFlag,
+		flags.SourceCredentialsSecret) && !changed(flags.SourceGitURLFlag, flags.SourceURLFlag) &&
+		(dst.Source == nil || dst.Source.Git == nil) {
+		return fmt.Errorf("the Build source is not Git, --%s must be informed to switch it",
+			flags.SourceGitURLFlag)
+	}
+	if changed(flags.SourceOCIArtifactPullSecretFlag, flags.SourceOCIArtifactPruneFlag, flags.SourceBundlePruneFlag) &&
+		!changed(flags.SourceOCIArtifactImageFlag, flags.SourceBundleImageFlag) &&
+		(dst.Source == nil || dst.Source.OCIArtifact == nil) {
+		return fmt.Errorf("the Build source is not an OCI artifact, --%s must be informed to switch it",
+			flags.SourceOCIArtifactImageFlag)
+	}
+	if changed(flags.SourceGitURLFlag, flags.SourceURLFlag, flags.SourceGitRevisionFlag,
+		flags.SourceRevisionFlag, flags.SourceGitCloneSecretFlag, flags.SourceCredentialsSecret,
+		flags.SourceContextDirFlag, flags.SourceOCIArtifactImageFlag, flags.SourceBundleImageFlag,
+		flags.SourceOCIArtifactPullSecretFlag, flags.SourceOCIArtifactPruneFlag, flags.SourceBundlePruneFlag) &&
+		dst.Source == nil {
+		dst.Source = &buildv1beta1.Source{}
+	}
+	if changed(flags.SourceGitURLFlag, flags.SourceURLFlag, flags.SourceGitRevisionFlag,
+		flags.SourceRevisionFlag, flags.SourceGitCloneSecretFlag, flags.SourceCredentialsSecret) &&
+		dst.Source.Git == nil {
+		dst.Source.Git = &buildv1beta1.Git{}
+	}
+	if changed(flags.SourceGitURLFlag, flags.SourceURLFlag) {
+		dst.Source.Type = buildv1beta1.GitType
+		dst.Source.Git.URL = srcGit.URL
+		dst.Source.OCIArtifact = nil
+	}
+	if changed(flags.SourceGitRevisionFlag, flags.SourceRevisionFlag) {
+		dst.Source.Git.Revision = nilIfEmpty(srcGit.Revision)
+	}
+	if changed(flags.SourceGitCloneSecretFlag, flags.SourceCredentialsSecret) {
+		dst.Source.Git.CloneSecret = nilIfEmpty(srcGit.CloneSecret)
+	}
+	if changed(flags.SourceContextDirFlag) {
+		dst.Source.ContextDir = nilIfEmpty(srcSource.ContextDir)
+	}
+	if changed(flags.SourceOCIArtifactImageFlag, flags.SourceBundleImageFlag,
+		flags.SourceOCIArtifactPullSecretFlag, flags.SourceOCIArtifactPruneFlag, flags.SourceBundlePruneFlag) &&
+		dst.Source.OCIArtifact == nil {
+		dst.Source.OCIArtifact = &buildv1beta1.OCIArtifact{}
+	}
+	if changed(flags.SourceOCIArtifactImageFlag, flags.SourceBundleImageFlag) {
+		dst.Source.Type = buildv1beta1.OCIArtifactType
+		dst.Source.OCIArtifact.Image = srcOCIArtifact.Image
+		dst.Source.Git = nil
+	}
+	if changed(flags.SourceOCIArtifactPullSecretFlag) {
+		dst.Source.OCIArtifact.PullSecret = nilIfEmpty(srcOCIArtifact.PullSecret)
+	}
+	if changed(flags.SourceOCIArtifactPruneFlag, flags.SourceBundlePruneFlag) {
+		dst.Source.OCIArtifact.Prune = srcOCIArtifact.Prune
+	}
+
+	// strategy
+	if changed(flags.StrategyKindFlag) {
+		dst.Strategy.Kind = src.Strategy.Kind
+	}
+	if changed(flags.StrategyNameFlag) {
+		dst.Strategy.Name = src.Strategy.Name
+	}
+
+	// output image
+	if changed(flags.OutputImageFlag) {
+		dst.Output.Image = src.Output.Image
+	}
+	if changed(flags.OutputImagePushSecretFlag, flags.OutputCredentialsSecretFlag) {
+		dst.Output.PushSecret = src.Output.PushSecret
+	}
+	if changed(flags.OutputInsecureFlag) {
+		dst.Output.Insecure = src.Output.Insecure
+	}
+	if changed(flags.OutputTimestampFlag) {
+		dst.Output.Timestamp = src.Output.Timestamp
+	}
+	if changed(flags.OutputImageLabelsFlag) {
+		dst.Output.Labels = flags.MergeMap(dst.Output.Labels, src.Output.Labels)
+	}
+	if changed(flags.OutputImageAnnotationsFlag) {
+		dst.Output.Annotations = flags.MergeMap(dst.Output.Annotations, src.Output.Annotations)
+	}
+	dst.Output.VulnerabilityScan = flags.MergeVulnerabilityScan(fs, dst.Output.VulnerabilityScan, src.Output.VulnerabilityScan)
+
+	if changed(flags.TimeoutFlag) {
+		dst.Timeout = src.Timeout
+	}
+
+	// environment variables and parameters are replaced by name, or appended
+	if changed(flags.EnvFlag) {
+		for _, env := range src.Env {
+			flags.UpsertEnv(&dst.Env, env)
+		}
+	}
+	if changed(flags.ParamValueFlag) {
+		for _, pv := range src.ParamValues {
+			flags.UpsertParam(&dst.ParamValues, pv)
+		}
+	}
+
+	// retention
+	if changed(flags.RetentionFailedLimitFlag, flags.RetentionSucceededLimitFlag,
+		flags.RetentionTTLAfterFailedFlag, flags.RetentionTTLAfterSucceededFlag) && dst.Retention == nil {
+		dst.Retention = &buildv1beta1.BuildRetention{}
+	}
+	if changed(flags.RetentionFailedLimitFlag) {
+		dst.Retention.FailedLimit = srcRetention.FailedLimit
+	}
+	if changed(flags.RetentionSucceededLimitFlag) {
+		dst.Retention.SucceededLimit = srcRetention.SucceededLimit
+	}
+	if changed(flags.RetentionTTLAfterFailedFlag) {
+		dst.Retention.TTLAfterFailed = srcRetention.TTLAfterFailed
+	}
+	if changed(flags.RetentionTTLAfterSucceededFlag) {
+		dst.Retention.TTLAfterSucceeded = srcRetention.TTLAfterSucceeded
+	}
+
+	// strategy volumes are replaced by name, or appended
+	if changed(flags.VolumeFlag) {
+		for _, volume := range src.Volumes {
+			flags.UpsertVolume(&dst.Volumes, volume)
+		}
+	}
+
+	// scheduling
+	if changed(flags.NodeSelectorFlag) {
+		dst.NodeSelector = flags.MergeMap(dst.NodeSelector, src.NodeSelector)
+	}
+	if changed(flags.TolerationFlag) {
+		for _, toleration := range src.Tolerations {
+			flags.UpsertToleration(&dst.Tolerations, toleration)
+		}
+	}
+	if changed(flags.SchedulerNameFlag) {
+		dst.SchedulerName = src.SchedulerName
+	}
+	if changed(flags.RuntimeClassNameFlag) {
+		dst.RuntimeClassName = src.RuntimeClassName
+	}
+	return nil
+}
+
+// nilIfEmpty returns nil for empty strings, so the attribute is removed from the Build.
+func nilIfEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+// removeBuildSpecEntries removes the environment variables, parameter values and node selector
+// entries informed by name.
+func removeBuildSpecEntries(spec *buildv1beta1.BuildSpec, envs, paramValues, nodeSelectorKeys []string) {
+	for _, name := range envs {
+		for i := range spec.Env {
+			if spec.Env[i].Name == name {
+				spec.Env = append(spec.Env[:i], spec.Env[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, name := range paramValues {
+		for i := range spec.ParamValues {
+			if spec.ParamValues[i].Name == name {
+				spec.ParamValues = append(spec.ParamValues[:i], spec.ParamValues[i+1:]...)
+				break
+			}
+		}
+	}
+	for _, key := range nodeSelectorKeys {
+		delete(spec.NodeSelector, key)
+	}
+	if len(spec.ParamValues) == 0 {
+		spec.ParamValues = nil
+	}
+	if len(spec.NodeSelector) == 0 {
+		spec.NodeSelector = nil
+	}
+}
+
+// upsertParamValue sets a single value parameter, used for the deprecated dockerfile and
+// builder-image flags.
+func upsertParamValue(paramValues *[]buildv1beta1.ParamValue, name, value string) {
+	flags.UpsertParam(paramValues, buildv1beta1.ParamValue{
+		Name:        name,
+		SingleValue: &buildv1beta1.SingleValue{Value: &value},
+	})
+}
+
+// updateCmd instantiate the "build update" subcommand.
+func updateCmd() runner.SubCommand {
+	cmd := &cobra.Command{
+		Use:   "update <name> [flags]",
+		Short: "Update an existing Build",
+		Long:  buildUpdateLongDesc,
+	}
+
+	buildSpecFlags, dockerfileFlag, builderImageFlag := flags.BuildSpecFromFlags(cmd.Flags())
+	c := &UpdateCommand{
+		cmd:          cmd,
+		buildSpec:    buildSpecFlags,
+		dockerfile:   dockerfileFlag,
+		builderImage: builderImageFlag,
+	}
+	cmd.Flags().StringArrayVar(&c.envRemove, flags.EnvRemoveFlag, nil,
+		"name of an environment variable to remove from the Build")
+	cmd.Flags().StringArrayVar(&c.paramValueRemove, flags.ParamValueRemoveFlag, nil,
+		"name of a parameter value to remove from the Build")
+	cmd.Flags().StringArrayVar(&c.nodeSelectorRemove, flags.NodeSelectorRemoveFlag, nil,
+		"key of a node selector entry to remove from the Build")
+	return c
+}
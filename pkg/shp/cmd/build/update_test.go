@@ -0,0 +1,284 @@
+package build // nolint:revive
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/ptr"
+)
+
+func TestUpdateBuild(t *testing.T) {
+	g := o.NewWithT(t)
+
+	name := "test-build"
+	namespace := "default"
+	build := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: buildv1beta1.BuildSpec{
+			Source: &buildv1beta1.Source{
+				Type: buildv1beta1.GitType,
+				Git:  &buildv1beta1.Git{URL: "https://github.com/shipwright-io/sample-go"},
+			},
+			Strategy: buildv1beta1.Strategy{Name: "buildah"},
+			Output:   buildv1beta1.Image{Image: "registry/old:latest"},
+			Env: []corev1.EnvVar{
+				{Name: "KEEP", Value: "value"},
+				{Name: "REMOVE", Value: "value"},
+			},
+			ParamValues: []buildv1beta1.ParamValue{{
+				Name:        "dockerfile",
+				SingleValue: &buildv1beta1.SingleValue{Value: ptr.To("Dockerfile")},
+			}},
+			NodeSelector: map[string]string{"kubernetes.io/hostname": "worker-1"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "builds",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		},
+	}
+
+	shpclient := shpfake.NewSimpleClientset(build)
+	p := params.NewParamsForTest(nil, shpclient, nil, nil, namespace, nil, nil)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	cmd := updateCmd().(*UpdateCommand)
+	cmd.cmd.SetContext(context.Background())
+	fs := cmd.Cmd().Flags()
+	g.Expect(fs.Set(flags.OutputImageFlag, "registry/new:latest")).To(o.Succeed())
+	g.Expect(fs.Set(flags.EnvFlag, "KEEP=changed")).To(o.Succeed())
+	g.Expect(fs.Set(flags.EnvRemoveFlag, "REMOVE")).To(o.Succeed())
+	g.Expect(fs.Set(flags.ParamValueFlag, "platforms=linux/amd64")).To(o.Succeed())
+	g.Expect(fs.Set(flags.NodeSelectorRemoveFlag, "kubernetes.io/hostname")).To(o.Succeed())
+	g.Expect(fs.Set(flags.TolerationFlag, "dedicated=ci:NoSchedule")).To(o.Succeed())
+	g.Expect(fs.Set(flags.TolerationFlag, "gpu:NoSchedule")).To(o.Succeed())
+	g.Expect(fs.Set(flags.RuntimeClassNameFlag, "kata")).To(o.Succeed())
+	g.Expect(fs.Set(flags.VolumeFlag, "cache=emptydir")).To(o.Succeed())
+	g.Expect(fs.Set(flags.OutputVulnScanFlag, "true")).To(o.Succeed())
+	g.Expect(fs.Set(flags.OutputVulnIgnoreSeverityFlag, "low")).To(o.Succeed())
+
+	g.Expect(cmd.Complete(p, &ioStreams, []string{name})).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.Succeed())
+	g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+	g.Expect(out.String()).To(o.ContainSubstring("-  image: registry/old:latest"))
+	g.Expect(out.String()).To(o.ContainSubstring("+  image: registry/new:latest"))
+	g.Expect(out.String()).To(o.ContainSubstring("Updated build \"test-build\""))
+
+	updated, err := shpclient.ShipwrightV1beta1().Builds(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	g.Expect(err).To(o.BeNil())
+	g.Expect(updated.Spec.Output.Image).To(o.Equal("registry/new:latest"))
+	g.Expect(updated.Spec.Strategy.Name).To(o.Equal("buildah"))
+	g.Expect(updated.Spec.Source.Git.URL).To(o.Equal("https://github.com/shipwright-io/sample-go"))
+	g.Expect(updated.Spec.Env).To(o.Equal([]corev1.EnvVar{{Name: "KEEP", Value: "changed"}}))
+	g.Expect(updated.Spec.ParamValues).To(o.HaveLen(2))
+	g.Expect(updated.Spec.NodeSelector).To(o.BeEmpty())
+	g.Expect(updated.Spec.Tolerations).To(o.Equal([]corev1.Toleration{{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "ci",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}, {
+		Key:      "gpu",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}}))
+	g.Expect(updated.Spec.RuntimeClassName).To(o.Equal(ptr.To("kata")))
+	g.Expect(updated.Spec.Volumes).To(o.Equal([]buildv1beta1.BuildVolume{{
+		Name:         "cache",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}))
+	g.Expect(updated.Spec.Output.VulnerabilityScan).To(o.Equal(&buildv1beta1.VulnerabilityScanOptions{
+		Enabled: true,
+		Ignore:  &buildv1beta1.VulnerabilityIgnoreOptions{Severity: ptr.To(buildv1beta1.IgnoredLow)},
+	}))
+}
+
+func TestUpdateBuildUnchanged(t *testing.T) {
+	g := o.NewWithT(t)
+
+	build := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-build", Namespace: "default"},
+		Spec:       buildv1beta1.BuildSpec{Output: buildv1beta1.Image{Image: "registry/app:latest"}},
+	}
+	p := params.NewParamsForTest(nil, shpfake.NewSimpleClientset(build), nil, nil, "default", nil, nil)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+
+	cmd := updateCmd().(*UpdateCommand)
+	cmd.cmd.SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputImageFlag, "registry/app:latest")).To(o.Succeed())
+	g.Expect(cmd.Complete(p, &ioStreams, []string{"test-build"})).To(o.Succeed())
+	g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+	g.Expect(strings.TrimSpace(out.String())).To(o.Equal("Build \"test-build\" is unchanged"))
+}
+
+func TestUpdateBuildNoFlags(t *testing.T) {
+	g := o.NewWithT(t)
+
+	root := &cobra.Command{Use: "shp"}
+	root.PersistentFlags().StringP("namespace", "n", "", "")
+	cmd := updateCmd().(*UpdateCommand)
+	root.AddCommand(cmd.Cmd())
+
+	// only the inherited namespace flag is informed, parsed as part of the command flags
+	g.Expect(cmd.Cmd().ParseFlags([]string{"--namespace", "other"})).To(o.Succeed())
+	g.Expect(cmd.Cmd().Flags().NFlag()).To(o.Equal(1))
+	g.Expect(cmd.Complete(nil, nil, []string{"test-build"})).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.MatchError("at least one flag must be informed to update the Build"))
+
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputImageFlag, "registry/app:latest")).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.Succeed())
+}
+
+func TestUpdateBuildLocalSource(t *testing.T) {
+	g := o.NewWithT(t)
+
+	build := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-build", Namespace: "default"},
+		Spec: buildv1beta1.BuildSpec{
+			Source: &buildv1beta1.Source{
+				Type:  buildv1beta1.LocalType,
+				Local: &buildv1beta1.Local{Name: "local-copy"},
+			},
+			Strategy: buildv1beta1.Strategy{Name: "buildah"},
+			Output: buildv1beta1.Image{
+				Image:    "registry/app:latest",
+				Insecure: ptr.To(false),
+			},
+			Retention: &buildv1beta1.BuildRetention{FailedLimit: ptr.To[uint](65535)},
+		},
+	}
+	shpclient := shpfake.NewSimpleClientset(build)
+	p := params.NewParamsForTest(nil, shpclient, nil, nil, "default", nil, nil)
+	ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+	cmd := updateCmd().(*UpdateCommand)
+	cmd.cmd.SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set(flags.OutputImageFlag, "registry/app:v2")).To(o.Succeed())
+	g.Expect(cmd.Complete(p, &ioStreams, []string{"test-build"})).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.Succeed())
+	g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+	// the attributes not informed on the command-line are kept as is
+	updated, err := shpclient.ShipwrightV1beta1().Builds("default").Get(context.Background(), "test-build", metav1.GetOptions{})
+	g.Expect(err).To(o.BeNil())
+	g.Expect(updated.Spec.Output.Image).To(o.Equal("registry/app:v2"))
+	g.Expect(updated.Spec.Source).To(o.Equal(build.Spec.Source))
+	g.Expect(updated.Spec.Output.Insecure).To(o.Equal(ptr.To(false)))
+	g.Expect(updated.Spec.Retention).To(o.Equal(build.Spec.Retention))
+}
+
+func TestUpdateBuildSourceType(t *testing.T) {
+	gitSource := &buildv1beta1.Source{
+		Type: buildv1beta1.GitType,
+		Git:  &buildv1beta1.Git{URL: "https://github.com/shipwright-io/sample-go"},
+	}
+	ociSource := &buildv1beta1.Source{
+		Type:        buildv1beta1.OCIArtifactType,
+		OCIArtifact: &buildv1beta1.OCIArtifact{Image: "registry/source:latest"},
+	}
+
+	tests := []struct {
+		name    string
+		source  *buildv1beta1.Source
+		args    map[string]string
+		wantErr string
+		verify  func(g *o.WithT, source *buildv1beta1.Source)
+	}{{
+		name:    "git revision on an OCI artifact source",
+		source:  ociSource,
+		args:    map[string]string{flags.SourceGitRevisionFlag: "main"},
+		wantErr: "the Build source is not Git",
+	}, {
+		name:    "OCI artifact pull secret on a git source",
+		source:  gitSource,
+		args:    map[string]string{flags.SourceOCIArtifactPullSecretFlag: "registry-creds"},
+		wantErr: "the Build source is not an OCI artifact",
+	}, {
+		name:   "switching from OCI artifact to git",
+		source: ociSource,
+		args: map[string]string{
+			flags.SourceGitURLFlag:      "https://github.com/shipwright-io/sample-nodejs",
+			flags.SourceGitRevisionFlag: "main",
+		},
+		verify: func(g *o.WithT, source *buildv1beta1.Source) {
+			g.Expect(source.Type).To(o.Equal(buildv1beta1.GitType))
+			g.Expect(source.OCIArtifact).To(o.BeNil())
+			g.Expect(source.Git.URL).To(o.Equal("https://github.com/shipwright-io/sample-nodejs"))
+			g.Expect(source.Git.Revision).To(o.Equal(ptr.To("main")))
+		},
+	}, {
+		name:   "git revision on a git source",
+		source: gitSource,
+		args:   map[string]string{flags.SourceGitRevisionFlag: "v1.0.0"},
+		verify: func(g *o.WithT, source *buildv1beta1.Source) {
+			g.Expect(source.Git.URL).To(o.Equal(gitSource.Git.URL))
+			g.Expect(source.Git.Revision).To(o.Equal(ptr.To("v1.0.0")))
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			build := &buildv1beta1.Build{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-build", Namespace: "default"},
+				Spec: buildv1beta1.BuildSpec{
+					Source:   tt.source.DeepCopy(),
+					Strategy: buildv1beta1.Strategy{Name: "buildah"},
+					Output:   buildv1beta1.Image{Image: "registry/app:latest"},
+				},
+			}
+			shpclient := shpfake.NewSimpleClientset(build)
+			p := params.NewParamsForTest(nil, shpclient, nil, nil, "default", nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+
+			cmd := updateCmd().(*UpdateCommand)
+			cmd.cmd.SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+			g.Expect(cmd.Complete(p, &ioStreams, []string{"test-build"})).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+
+			err := cmd.Run(p, &ioStreams)
+			if tt.wantErr != "" {
+				g.Expect(err).To(o.MatchError(o.ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(o.HaveOccurred())
+			updated, err := shpclient.ShipwrightV1beta1().Builds("default").Get(context.Background(), "test-build", metav1.GetOptions{})
+			g.Expect(err).ToNot(o.HaveOccurred())
+			tt.verify(g, updated.Spec.Source)
+		})
+	}
+}
+
+func TestBuildMergePatchResourceVersion(t *testing.T) {
+	g := o.NewWithT(t)
+
+	current := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-build", Namespace: "default", ResourceVersion: "42"},
+		Spec:       buildv1beta1.BuildSpec{Output: buildv1beta1.Image{Image: "registry/app:v1"}},
+	}
+	updated := current.DeepCopy()
+	updated.Spec.Output.Image = "registry/app:v2"
+
+	// the resource version makes the API server reject the patch when the Build changed meanwhile
+	patch, err := buildMergePatch(current, updated)
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(string(patch)).To(o.MatchJSON(
+		`{"metadata":{"resourceVersion":"42"},"spec":{"output":{"image":"registry/app:v2"}}}`))
+}
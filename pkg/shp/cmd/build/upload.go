@@ -16,6 +16,7 @@ import (
 	"github.com/shipwright-io/cli/pkg/shp/params"
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
 	"github.com/shipwright-io/cli/pkg/shp/streamer"
+	shputil "github.com/shipwright-io/cli/pkg/shp/util"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,8 @@ type UploadCommand struct {
 	cmd          *cobra.Command             // cobra command instance
 	buildRunSpec *buildv1beta1.BuildRunSpec // command-line flags stored directly on the BuildRun
 	follow       bool                       // flag to tail pod logs
+	followOutput flags.FollowOutput         // how the followed log lines are shown
+	gracePeriod  time.Duration              // time a pod may be unschedulable or failing to pull images
 
 	buildRefName string // build name
 	sourceDir    string // local directory to be streamed
@@ -193,6 +196,9 @@ func (u *UploadCommand) createBuildRun(p *params.Params) (*buildv1beta1.BuildRun
 	if err != nil {
 		return nil, err
 	}
+	if err = shputil.ValidateBuildRunVolumes(u.cmd.Context(), clientset, ns, &br.Spec); err != nil {
+		return nil, err
+	}
 	br, err = clientset.ShipwrightV1beta1().
 		BuildRuns(ns).
 		Create(u.cmd.Context(), br, metav1.CreateOptions{})
@@ -293,6 +299,8 @@ func (u *UploadCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStr
 		if u.follower, err = p.NewFollower(u.Cmd().Context(), types.NamespacedName{Namespace: br.Namespace, Name: br.Name}, ioStreams); err != nil {
 			return err
 		}
+		u.follower.SetOutputOptions(u.followOutput.Prefix, !u.followOutput.NoColor)
+		u.follower.SetPendingGracePeriod(u.gracePeriod)
 	}
 
 	switch {
@@ -340,5 +348,7 @@ func uploadCmd() runner.SubCommand {
 		follow:       false,
 	}
 	flags.FollowFlag(cmd.Flags(), &u.follow)
+	flags.FollowOutputFlags(cmd.Flags(), &u.followOutput)
+	flags.PendingGracePeriodFlag(cmd.Flags(), &u.gracePeriod, follower.DefaultPendingGracePeriod)
 	return u
 }
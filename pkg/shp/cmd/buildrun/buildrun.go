@@ -20,11 +20,21 @@ func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Co
 		},
 	}
 
+	logsCommand := runner.NewRunner(p, ioStreams, logsCmd()).Cmd()
+	if err := logsCommand.RegisterFlagCompletionFunc("step", stepCompletionFunc(p)); err != nil {
+		panic(err)
+	}
+
 	// TODO: add support for `update` and `get` commands
 	command.AddCommand(
 		runner.NewRunner(p, ioStreams, listCmd()).Cmd(),
-		runner.NewRunner(p, ioStreams, logsCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, describeCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, exportCmd()).Cmd(),
+		logsCommand,
+		runner.NewRunner(p, ioStreams, vulnerabilitiesCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, createCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, rerunCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, waitCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, cancelCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, deleteCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, gatherCmd()).Cmd(),
@@ -2,6 +2,7 @@ package buildrun
 
 import (
 	"fmt"
+	"time"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/spf13/cobra"
@@ -10,8 +11,11 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/waiter"
 	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
+	shputil "github.com/shipwright-io/cli/pkg/shp/util"
 )
 
 // CreateCommand reprents the build's create subcommand.
@@ -20,6 +24,12 @@ type CreateCommand struct {
 
 	name         string                     // buildrun name
 	buildRunSpec *buildv1beta1.BuildRunSpec // stores command-line flags
+
+	dryRun     flags.DryRunStrategy // dry-run strategy
+	printFlags *printer.PrintFlags  // output format of the created object
+
+	wait        bool          // flag to wait for the buildrun completion
+	waitTimeout time.Duration // maximum time waiting for the buildrun completion
 }
 
 const buildRunCreateLongDesc = `
@@ -27,6 +37,16 @@ Creates a new BuildRun instance using the given name, and requires --buildref-na
 find the Build object. Example:
 
 	$ shp buildrun create my-app-build --buildref-name="..."
+
+The command can block until the BuildRun is completed, without streaming its logs, exiting with
+non-zero status when the BuildRun fails:
+
+	$ shp buildrun create my-app-build --buildref-name="..." --wait --wait-timeout=30m
+
+The BuildRun manifest can be generated without creating it, or validated by the cluster without
+persisting it, using the dry-run flag alongside an output format:
+
+	$ shp buildrun create my-app-build --buildref-name="..." --dry-run=client -o yaml
 `
 
 // Cmd returns cobra.Command object of the create sub-command.
@@ -50,7 +70,13 @@ func (c *CreateCommand) Validate() error {
 	if c.name == "" {
 		return fmt.Errorf("name is not informed")
 	}
-	return nil
+	if c.wait && c.dryRun != flags.DryRunNone {
+		return fmt.Errorf("--wait cannot be used together with --dry-run")
+	}
+	if c.printFlags.IsWide() {
+		return fmt.Errorf("output format %q is not supported", c.printFlags.OutputFormat)
+	}
+	return c.printFlags.Validate()
 }
 
 // Run executes the creation of BuildRun object.
@@ -64,16 +90,44 @@ func (c *CreateCommand) Run(params *params.Params, ioStreams *genericclioptions.
 
 	flags.SanitizeBuildRunSpec(&br.Spec)
 
+	if c.dryRun != flags.DryRunClient {
+		clientset, err := params.ShipwrightClientSet()
+		if err != nil {
+			return err
+		}
+		err = shputil.ValidateBuildRunVolumes(c.cmd.Context(), clientset, params.Namespace(), &br.Spec)
+		if err != nil {
+			return err
+		}
+		br, err = clientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).Create(c.cmd.Context(), br, metav1.CreateOptions{
+			DryRun: c.dryRun.DryRun(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if !c.printFlags.IsHumanReadable() {
+		if err := c.printFlags.Print(br, false, ioStreams.Out); err != nil {
+			return err
+		}
+	} else {
+		// Cli doesn't allow standalone buildruns, so it will always refer an existing build.
+		fmt.Fprintf(ioStreams.Out, "BuildRun created %q for Build %q%s\n", c.name, *br.Spec.Build.Name, c.dryRun.Suffix())
+	}
+	if !c.wait {
+		return nil
+	}
+
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
 	}
-	if _, err = clientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).Create(c.cmd.Context(), br, metav1.CreateOptions{}); err != nil {
+	if _, err = waiter.Wait(c.cmd.Context(), clientset, params.Namespace(), c.name, c.waitTimeout); err != nil {
 		return err
 	}
-
-	// Cli doesn't allow standalone buildruns, so it will always refer an existing build.
-	fmt.Fprintf(ioStreams.Out, "BuildRun created %q for Build %q\n", c.name, *br.Spec.Build.Name)
+	if c.printFlags.IsHumanReadable() {
+		fmt.Fprintf(ioStreams.Out, "BuildRun %q has succeeded\n", c.name)
+	}
 	return nil
 }
 
@@ -93,8 +147,13 @@ func createCmd() runner.SubCommand {
 		panic(err)
 	}
 
-	return &CreateCommand{
+	c := &CreateCommand{
 		cmd:          cmd,
 		buildRunSpec: buildRunSpecFlags,
+		printFlags:   printer.NewPrintFlags(),
 	}
+	flags.DryRunFlags(cmd.Flags(), &c.dryRun)
+	flags.WaitFlags(cmd.Flags(), &c.wait, &c.waitTimeout)
+	c.printFlags.AddFlags(cmd)
+	return c
 }
@@ -0,0 +1,233 @@
+package buildrun
+
+import (
+	"fmt"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/describe"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// DescribeCommand contains data input from user for describe sub-command
+type DescribeCommand struct {
+	cmd *cobra.Command
+
+	name string
+}
+
+const buildRunDescribeLongDesc = `
+Shows the details of a BuildRun, including its conditions, failure details, source and output
+results, executor, timing, the effective Build configuration, related pods and events. Example:
+
+	$ shp buildrun describe my-app-xyz12
+`
+
+func describeCmd() runner.SubCommand {
+	return &DescribeCommand{
+		cmd: &cobra.Command{
+			Use:   "describe <name>",
+			Short: "Show details of a BuildRun",
+			Long:  buildRunDescribeLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+}
+
+// Cmd returns cobra command object
+func (c *DescribeCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *DescribeCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *DescribeCommand) Validate() error {
+	return nil
+}
+
+// Run executes describe sub-command logic
+func (c *DescribeCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	ctx := c.cmd.Context()
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	k8sclient, err := params.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	br, err := clientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	pods, err := k8sclient.CoreV1().Pods(params.Namespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", buildv1beta1.LabelBuildRun, c.name),
+	})
+	if err != nil {
+		return err
+	}
+
+	involved := []describe.InvolvedObject{{Kind: "BuildRun", Name: br.GetName()}}
+	if kind, name := executorForBuildRun(br); name != "" {
+		involved = append(involved, describe.InvolvedObject{Kind: kind, Name: name})
+	}
+	for _, pod := range pods.Items {
+		involved = append(involved, describe.InvolvedObject{Kind: "Pod", Name: pod.GetName()})
+	}
+	events, err := describe.Events(ctx, k8sclient, params.Namespace(), involved...)
+	if err != nil {
+		return err
+	}
+
+	return describe.Tabbed(ioStreams.Out, func(w kdescribe.PrefixWriter) error {
+		describeBuildRun(w, br)
+		describePods(w, pods.Items)
+		kdescribe.DescribeEvents(events, w)
+		return nil
+	})
+}
+
+// describeBuildRun writes the BuildRun details organized in sections.
+func describeBuildRun(w kdescribe.PrefixWriter, br *buildv1beta1.BuildRun) {
+	describe.ObjectMeta(w, br.ObjectMeta)
+
+	buildName := br.Spec.BuildName()
+	if br.Spec.Build.Spec != nil {
+		buildName = "<embedded>"
+	}
+	w.Write(kdescribe.LEVEL_0, "Build:\t%s\n", buildName)
+	if br.Spec.State != nil {
+		w.Write(kdescribe.LEVEL_0, "Requested State:\t%s\n", *br.Spec.State)
+	}
+
+	w.Write(kdescribe.LEVEL_0, "Status:\n")
+	status := string(metav1.ConditionUnknown)
+	if c := br.Status.GetCondition(buildv1beta1.Succeeded); c != nil && c.Reason != "" {
+		status = c.Reason
+	}
+	w.Write(kdescribe.LEVEL_1, "Reason:\t%s\n", status)
+	w.Write(kdescribe.LEVEL_1, "Start Time:\t%s\n", describe.Timestamp(br.Status.StartTime))
+	w.Write(kdescribe.LEVEL_1, "Completion Time:\t%s\n", describe.Timestamp(br.Status.CompletionTime))
+	if br.Status.StartTime != nil && br.Status.CompletionTime != nil {
+		w.Write(kdescribe.LEVEL_1, "Duration:\t%s\n", br.Status.CompletionTime.Sub(br.Status.StartTime.Time))
+	}
+	if kind, name := executorForBuildRun(br); name != "" {
+		w.Write(kdescribe.LEVEL_1, "Executor:\t%s/%s\n", kind, name)
+	} else {
+		w.Write(kdescribe.LEVEL_1, "Executor:\t<none>\n")
+	}
+
+	if len(br.Status.Conditions) == 0 {
+		w.Write(kdescribe.LEVEL_0, "Conditions:\t<none>\n")
+	} else {
+		w.Write(kdescribe.LEVEL_0, "Conditions:\n")
+		w.Write(kdescribe.LEVEL_1, "Type\tStatus\tReason\tLast Transition\tMessage\n")
+		w.Write(kdescribe.LEVEL_1, "----\t------\t------\t---------------\t-------\n")
+		for _, c := range br.Status.Conditions {
+			w.Write(kdescribe.LEVEL_1, "%s\t%s\t%s\t%s\t%s\n",
+				c.Type,
+				c.Status,
+				c.Reason,
+				describe.Timestamp(&c.LastTransitionTime),
+				c.Message,
+			)
+		}
+	}
+
+	if fd := br.Status.FailureDetails; fd != nil {
+		w.Write(kdescribe.LEVEL_0, "Failure Details:\n")
+		w.Write(kdescribe.LEVEL_1, "Reason:\t%s\n", fd.Reason)
+		w.Write(kdescribe.LEVEL_1, "Message:\t%s\n", fd.Message)
+		if fd.Location != nil {
+			w.Write(kdescribe.LEVEL_1, "Pod:\t%s\n", fd.Location.Pod)
+			w.Write(kdescribe.LEVEL_1, "Container:\t%s\n", fd.Location.Container)
+		}
+	}
+
+	describeSourceResult(w, br.Status.Source)
+	describeOutputResult(w, br.Status.Output)
+
+	w.Write(kdescribe.LEVEL_0, "Overrides:\n")
+	w.Write(kdescribe.LEVEL_1, "Service Account:\t%s\n", describe.StringOrNone(br.Spec.ServiceAccount))
+	w.Write(kdescribe.LEVEL_1, "Timeout:\t%s\n", describe.Duration(br.Spec.Timeout))
+	describe.Image(w, kdescribe.LEVEL_1, "Output", br.Spec.Output)
+	describe.ParamValues(w, kdescribe.LEVEL_1, br.Spec.ParamValues)
+	describe.Env(w, kdescribe.LEVEL_1, br.Spec.Env)
+	describe.Volumes(w, kdescribe.LEVEL_1, br.Spec.Volumes)
+	describe.Map(w, kdescribe.LEVEL_1, "Node Selector", br.Spec.NodeSelector)
+	describe.Tolerations(w, kdescribe.LEVEL_1, br.Spec.Tolerations)
+	w.Write(kdescribe.LEVEL_1, "Scheduler Name:\t%s\n", describe.StringOrNone(br.Spec.SchedulerName))
+	w.Write(kdescribe.LEVEL_1, "Runtime Class:\t%s\n", describe.StringOrNone(br.Spec.RuntimeClassName))
+
+	if br.Status.BuildSpec != nil {
+		w.Write(kdescribe.LEVEL_0, "Build Spec:\n")
+		describe.BuildSpec(w, kdescribe.LEVEL_1, br.Status.BuildSpec)
+	}
+}
+
+// describeSourceResult writes the results of obtaining the source code.
+func describeSourceResult(w kdescribe.PrefixWriter, source *buildv1beta1.SourceResult) {
+	if source == nil {
+		w.Write(kdescribe.LEVEL_0, "Source Result:\t<none>\n")
+		return
+	}
+	w.Write(kdescribe.LEVEL_0, "Source Result:\n")
+	if source.Git != nil {
+		w.Write(kdescribe.LEVEL_1, "Commit SHA:\t%s\n", source.Git.CommitSha)
+		w.Write(kdescribe.LEVEL_1, "Commit Author:\t%s\n", source.Git.CommitAuthor)
+		w.Write(kdescribe.LEVEL_1, "Branch:\t%s\n", source.Git.BranchName)
+	}
+	if source.OciArtifact != nil {
+		w.Write(kdescribe.LEVEL_1, "Digest:\t%s\n", source.OciArtifact.Digest)
+	}
+	w.Write(kdescribe.LEVEL_1, "Timestamp:\t%s\n", describe.Timestamp(source.Timestamp))
+}
+
+// describeOutputResult writes the output image results.
+func describeOutputResult(w kdescribe.PrefixWriter, output *buildv1beta1.Output) {
+	if output == nil {
+		w.Write(kdescribe.LEVEL_0, "Output Result:\t<none>\n")
+		return
+	}
+	w.Write(kdescribe.LEVEL_0, "Output Result:\n")
+	w.Write(kdescribe.LEVEL_1, "Digest:\t%s\n", output.Digest)
+	w.Write(kdescribe.LEVEL_1, "Size:\t%s\n", resource.NewQuantity(output.Size, resource.BinarySI).String())
+}
+
+// describePods writes a short summary of the pods executing the BuildRun.
+func describePods(w kdescribe.PrefixWriter, pods []corev1.Pod) {
+	if len(pods) == 0 {
+		w.Write(kdescribe.LEVEL_0, "Pods:\t<none>\n")
+		return
+	}
+	w.Write(kdescribe.LEVEL_0, "Pods:\n")
+	w.Write(kdescribe.LEVEL_1, "Name\tPhase\tNode\tCreated\n")
+	w.Write(kdescribe.LEVEL_1, "----\t-----\t----\t-------\n")
+	for _, pod := range pods {
+		node := pod.Spec.NodeName
+		if node == "" {
+			node = "<none>"
+		}
+		w.Write(kdescribe.LEVEL_1, "%s\t%s\t%s\t%s\n",
+			pod.GetName(),
+			pod.Status.Phase,
+			node,
+			describe.Timestamp(&pod.CreationTimestamp),
+		)
+	}
+}
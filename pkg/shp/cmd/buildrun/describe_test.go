@@ -0,0 +1,109 @@
+package buildrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestDescribeBuildRun(t *testing.T) {
+	name := "test-br"
+	namespace := "default"
+
+	buildRun := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: buildv1beta1.BuildRunSpec{
+			Build: buildv1beta1.ReferencedBuild{Name: ptr.To("test-build")},
+			Output: &buildv1beta1.Image{
+				Image:     "registry.example.com/test/image",
+				Timestamp: ptr.To(buildv1beta1.OutputImageZeroTimestamp),
+			},
+			Volumes: []buildv1beta1.BuildVolume{{
+				Name:         "cache",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+			Tolerations: []corev1.Toleration{{
+				Key:      "node.kubernetes.io/unreachable",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		},
+		Status: buildv1beta1.BuildRunStatus{
+			Conditions: buildv1beta1.Conditions{{
+				Type:    buildv1beta1.Succeeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Failed",
+				Message: "step failed",
+			}},
+			Executor: &buildv1beta1.BuildExecutor{Kind: "TaskRun", Name: "test-tr"},
+			FailureDetails: &buildv1beta1.FailureDetails{
+				Reason:   "StepFailed",
+				Message:  "exit code 1",
+				Location: &buildv1beta1.Location{Pod: "test-pod", Container: "step-build-and-push"},
+			},
+			Source: &buildv1beta1.SourceResult{
+				Git: &buildv1beta1.GitSourceResult{
+					CommitSha:    "abcdef",
+					CommitAuthor: "someone",
+					BranchName:   "main",
+				},
+			},
+			Output: &buildv1beta1.Output{Digest: "sha256:123", Size: 2048},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: namespace,
+			Labels:    map[string]string{buildv1beta1.LabelBuildRun: name},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed},
+	}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "test-event", Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "test-pod"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "back-off pulling image",
+	}
+
+	p := params.NewParamsForTest(fake.NewSimpleClientset(pod, event), shpfake.NewSimpleClientset(buildRun), nil, nil, namespace, nil, nil)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	c := &cobra.Command{}
+	c.SetContext(context.Background())
+	cmd := &DescribeCommand{cmd: c, name: name}
+
+	if err := cmd.Run(p, &ioStreams); err != nil {
+		t.Fatalf("Describe.Run failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"Name:", name,
+		"Build:", "test-build",
+		"Executor:", "TaskRun/test-tr",
+		"StepFailed", "step-build-and-push",
+		"Commit SHA:", "abcdef",
+		"Branch:", "main",
+		"Digest:", "sha256:123",
+		"Size:", "2Ki",
+		"Timestamp:", buildv1beta1.OutputImageZeroTimestamp,
+		"Volumes:", "cache=emptydir",
+		"Tolerations:", "node.kubernetes.io/unreachable:NoSchedule",
+		"Pods:", "test-pod",
+		"Events:", "back-off pulling image",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q on describe output:\n%s", expected, out.String())
+		}
+	}
+}
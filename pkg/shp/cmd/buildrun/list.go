@@ -2,28 +2,48 @@ package buildrun
 
 import (
 	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
 )
 
 // ListCommand contains data input from user for list sub-command
 type ListCommand struct {
 	cmd *cobra.Command
 
-	noHeader bool
-	wide     bool
+	noHeader   bool
+	wide       bool
+	watch      bool
+	watchOnly  bool
+	build      string
+	status     string
+	since      time.Duration
+	limit      int
+	sortBy     string
+	filter     flags.ListFilter
+	printFlags *printer.PrintFlags
 }
 
 func listCmd() runner.SubCommand {
@@ -32,10 +52,20 @@ func listCmd() runner.SubCommand {
 			Use:   "list [flags]",
 			Short: "List Builds",
 		},
+		printFlags: printer.NewPrintFlags(),
 	}
 
 	listCmd.cmd.Flags().BoolVar(&listCmd.noHeader, "no-header", false, "Do not show columns header in list output")
-	listCmd.cmd.Flags().BoolVar(&listCmd.wide, "wide", false, "Display additional fields such as source, output-image, build-name, elapsed-time and source-origin in list output")
+	listCmd.cmd.Flags().BoolVar(&listCmd.wide, "wide", false, "Display additional fields such as source, output-image, build-name, elapsed-time, source-origin and image-timestamp in list output")
+	listCmd.cmd.Flags().BoolVarP(&listCmd.watch, "watch", "w", false, "After listing the BuildRuns, watch for changes and print them as their status changes")
+	listCmd.cmd.Flags().BoolVar(&listCmd.watchOnly, "watch-only", false, "Watch for BuildRun changes without listing them first")
+	listCmd.cmd.Flags().StringVar(&listCmd.build, "build", "", "Only list the BuildRuns of the informed Build")
+	listCmd.cmd.Flags().StringVar(&listCmd.status, "status", "", fmt.Sprintf("Only list the BuildRuns with the informed status, one of: %s", strings.Join(buildRunStatuses, ", ")))
+	listCmd.cmd.Flags().DurationVar(&listCmd.since, "since", 0, "Only list the BuildRuns created within the informed duration, like 30m or 2h")
+	listCmd.cmd.Flags().IntVar(&listCmd.limit, "limit", 0, "Maximum number of BuildRuns to list, applied after sorting, zero means no limit")
+	listCmd.cmd.Flags().StringVar(&listCmd.sortBy, "sort-by", "", fmt.Sprintf("Sort the BuildRuns, one of: %s", strings.Join(sortByFields, ", ")))
+	flags.ListFilterFlags(listCmd.cmd.Flags(), &listCmd.filter)
+	listCmd.printFlags.AddFlags(listCmd.cmd)
 
 	return listCmd
 }
@@ -47,23 +77,36 @@ func (c *ListCommand) Cmd() *cobra.Command {
 
 // Complete fills in data provided by user
 func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, _ []string) error {
+	// the build name is part of the label selector, narrowing down the BuildRuns on the server side
+	if c.build != "" {
+		selector := labels.Set{buildv1beta1.LabelBuild: c.build}.String()
+		if c.filter.LabelSelector != "" {
+			selector = c.filter.LabelSelector + "," + selector
+		}
+		c.filter.LabelSelector = selector
+	}
 	return nil
 }
 
 // Validate validates data input by user
 func (c *ListCommand) Validate() error {
-	return nil
+	if c.status != "" && !slices.Contains(buildRunStatuses, c.status) {
+		return fmt.Errorf("invalid status %q, must be one of: %s", c.status, strings.Join(buildRunStatuses, ", "))
+	}
+	if c.sortBy != "" && !slices.Contains(sortByFields, c.sortBy) {
+		return fmt.Errorf("invalid sort-by %q, must be one of: %s", c.sortBy, strings.Join(sortByFields, ", "))
+	}
+	if c.since < 0 {
+		return fmt.Errorf("since must not be negative")
+	}
+	if c.limit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	return c.printFlags.Validate()
 }
 
 // Run executes list sub-command logic
 func (c *ListCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
-	// TODO: Support multiple output formats here, not only tabwriter
-	//       find out more in kubectl libraries and use them
-
-	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
-	columnNames := "NAME\tSTATUS\tAGE"
-	columnTemplate := "%s\t%s\t%s\n"
-
 	clientset, err := params.ShipwrightClientSet()
 	if err != nil {
 		return err
@@ -73,80 +116,300 @@ func (c *ListCommand) Run(params *params.Params, ioStreams *genericclioptions.IO
 	if err != nil {
 		return fmt.Errorf("failed to get k8s client: %w", err)
 	}
-	_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), params.Namespace(), metav1.GetOptions{})
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			fmt.Fprintf(ioStreams.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", params.Namespace())
-			return nil
+	if !c.filter.AllNamespaces {
+		_, err = k8sclient.CoreV1().Namespaces().Get(c.cmd.Context(), params.Namespace(), metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				fmt.Fprintf(ioStreams.Out, "Namespace '%s' not found. Please ensure that the namespace exists and try again.\n", params.Namespace())
+				return nil
+			}
+			return err
 		}
-		return err
+	}
+
+	namespace := c.filter.Namespace(params.Namespace())
+	if c.watchOnly {
+		return c.watchBuildRuns(clientset, namespace, "", false, ioStreams)
 	}
 
 	var brs *buildv1beta1.BuildRunList
-	if brs, err = clientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).List(c.cmd.Context(), metav1.ListOptions{}); err != nil {
+	if brs, err = clientset.ShipwrightV1beta1().BuildRuns(namespace).List(c.cmd.Context(), c.filter.ListOptions()); err != nil {
 		return err
 	}
-	if len(brs.Items) == 0 {
-		fmt.Fprintf(ioStreams.Out, "No buildruns found in namespace '%s'. Please create a buildrun or verify the namespace.\n", params.Namespace())
+	brs.Items = c.filterAndSort(brs.Items)
+	if !c.printFlags.IsHumanReadable() {
+		if err = c.printFlags.Print(brs, c.noHeader, ioStreams.Out); err != nil || !c.watch {
+			return err
+		}
+		return c.watchBuildRuns(clientset, namespace, brs.ResourceVersion, true, ioStreams)
+	}
+	if len(brs.Items) == 0 && !c.watch {
+		if c.filter.AllNamespaces {
+			fmt.Fprintln(ioStreams.Out, "No buildruns found in any namespace.")
+		} else {
+			fmt.Fprintf(ioStreams.Out, "No buildruns found in namespace '%s'. Please create a buildrun or verify the namespace.\n", params.Namespace())
+		}
 		return nil
 	}
 
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
 	if !c.noHeader {
-		if c.wide {
-			columnNames = "NAME\tSTATUS\tAGE\tSOURCE\tOUTPUT-IMAGE\tBUILD-NAME\tELAPSED-TIME\tSOURCE-ORIGIN"
-		}
-		fmt.Fprintln(writer, columnNames)
+		fmt.Fprintln(writer, c.columnNames())
+	}
+	for i := range brs.Items {
+		c.printRow(writer, &brs.Items[i])
+	}
+	if err = writer.Flush(); err != nil || !c.watch {
+		return err
 	}
+	return c.watchBuildRuns(clientset, namespace, brs.ResourceVersion, !c.noHeader, ioStreams)
+}
+
+// isWide returns true when the additional columns should be shown.
+func (c *ListCommand) isWide() bool {
+	return c.wide || c.printFlags.IsWide()
+}
 
-	if c.wide {
-		columnTemplate = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n"
+// columnNames returns the table header, the namespace column is shown when listing across
+// namespaces.
+func (c *ListCommand) columnNames() string {
+	columnNames := "NAME\tSTATUS\tAGE"
+	if c.isWide() {
+		columnNames = "NAME\tSTATUS\tAGE\tSOURCE\tOUTPUT-IMAGE\tBUILD-NAME\tELAPSED-TIME\tSOURCE-ORIGIN\tIMAGE-TIMESTAMP"
+	}
+	if c.filter.AllNamespaces {
+		columnNames = "NAMESPACE\t" + columnNames
 	}
+	return columnNames
+}
 
-	for _, br := range brs.Items {
-		name := br.Name
-		buildName := br.Spec.BuildName()
-		outputImage := "-"
-		source := "-"
-		sourceOrigin := "-"
-		// Check if BuildSpec is present in Status as this is an optional pointer field.
-		if br.Status.BuildSpec != nil {
-			outputImage = br.Status.BuildSpec.Output.Image
+// printRow writes the table row representing the informed BuildRun.
+func (c *ListCommand) printRow(writer io.Writer, br *buildv1beta1.BuildRun) {
+	columnTemplate := "%s\t%s\t%s\n"
+	if c.isWide() {
+		columnTemplate = "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n"
+	}
+	if c.filter.AllNamespaces {
+		columnTemplate = "%s\t" + columnTemplate
+	}
 
-			// In case the Source is not preset in the BuildSpec, try to get it from BuildRun spec.
-			if br.Status.BuildSpec.Source != nil {
-				sourceOrigin = string(br.Status.BuildSpec.Source.Type)
-			} else if br.Spec.Source != nil {
-				sourceOrigin = string(br.Spec.Source.Type)
-			}
+	name := br.Name
+	buildName := br.Spec.BuildName()
+	outputImage := "-"
+	source := "-"
+	sourceOrigin := "-"
+	// Check if BuildSpec is present in Status as this is an optional pointer field.
+	if br.Status.BuildSpec != nil {
+		outputImage = br.Status.BuildSpec.Output.Image
 
-			if sourceOrigin == "Git" {
-				source = br.Status.BuildSpec.Source.Git.URL
-				revision := br.Status.BuildSpec.Source.Git.Revision
-				if revision != nil {
-					source += "@" + *revision
-				}
-			}
+		// In case the Source is not preset in the BuildSpec, try to get it from BuildRun spec.
+		if br.Status.BuildSpec.Source != nil {
+			sourceOrigin = string(br.Status.BuildSpec.Source.Type)
+		} else if br.Spec.Source != nil {
+			sourceOrigin = string(br.Spec.Source.Type)
 		}
 
-		status := string(metav1.ConditionUnknown)
-		for _, condition := range br.Status.Conditions {
-			if condition.Type == buildv1beta1.Succeeded {
-				status = condition.Reason
-				break
+		if sourceOrigin == "Git" {
+			source = br.Status.BuildSpec.Source.Git.URL
+			revision := br.Status.BuildSpec.Source.Git.Revision
+			if revision != nil {
+				source += "@" + *revision
 			}
 		}
-		age := duration.ShortHumanDuration(time.Since((br.ObjectMeta.CreationTimestamp).Time))
-		elapsedTime := age
-		if br.Status.StartTime != nil && br.Status.CompletionTime != nil {
-			duration := br.Status.CompletionTime.Sub(br.Status.StartTime.Time)
-			elapsedTime = duration.String()
+	}
+
+	status := buildRunStatus(br)
+	age := duration.ShortHumanDuration(time.Since((br.ObjectMeta.CreationTimestamp).Time))
+	elapsedTime := age
+	if br.Status.StartTime != nil && br.Status.CompletionTime != nil {
+		duration := br.Status.CompletionTime.Sub(br.Status.StartTime.Time)
+		elapsedTime = duration.String()
+	}
+	values := []interface{}{name, status, age}
+	if c.isWide() {
+		values = append(values, source, outputImage, buildName, elapsedTime, sourceOrigin, imageTimestamp(br))
+	}
+	if c.filter.AllNamespaces {
+		values = append([]interface{}{br.Namespace}, values...)
+	}
+	fmt.Fprintf(writer, columnTemplate, values...)
+}
+
+// imageTimestamp returns the output image timestamp setting, the BuildRun output overrides the one
+// of the Build.
+func imageTimestamp(br *buildv1beta1.BuildRun) string {
+	switch {
+	case br.Spec.Output != nil && br.Spec.Output.Timestamp != nil:
+		return *br.Spec.Output.Timestamp
+	case br.Status.BuildSpec != nil && br.Status.BuildSpec.Output.Timestamp != nil:
+		return *br.Status.BuildSpec.Output.Timestamp
+	default:
+		return "-"
+	}
+}
+
+// Statuses informed on the status flag, the BuildRun failure and pending reasons are grouped.
+const (
+	statusSucceeded = "Succeeded"
+	statusFailed    = "Failed"
+	statusRunning   = "Running"
+	statusPending   = "Pending"
+)
+
+var buildRunStatuses = []string{statusSucceeded, statusFailed, statusRunning, statusPending}
+
+// Fields informed on the sort-by flag.
+const (
+	sortByCreation = "creation"
+	sortByDuration = "duration"
+	sortByName     = "name"
+)
+
+var sortByFields = []string{sortByCreation, sortByDuration, sortByName}
+
+// statusGroup returns the status group of the BuildRun, based on the succeeded condition. A
+// BuildRun without the condition is still pending.
+func statusGroup(br *buildv1beta1.BuildRun) string {
+	condition := br.Status.GetCondition(buildv1beta1.Succeeded)
+	switch {
+	case condition == nil:
+		return statusPending
+	case condition.Status == corev1.ConditionTrue:
+		return statusSucceeded
+	case condition.Status == corev1.ConditionFalse:
+		return statusFailed
+	case condition.Reason == statusPending:
+		return statusPending
+	default:
+		return statusRunning
+	}
+}
+
+// elapsed returns how long the BuildRun took, or is taking so far, zero when not started.
+func elapsed(br *buildv1beta1.BuildRun) time.Duration {
+	switch {
+	case br.Status.StartTime == nil:
+		return 0
+	case br.Status.CompletionTime == nil:
+		return time.Since(br.Status.StartTime.Time)
+	default:
+		return br.Status.CompletionTime.Sub(br.Status.StartTime.Time)
+	}
+}
+
+// matches returns true when the BuildRun matches the status and since flags.
+func (c *ListCommand) matches(br *buildv1beta1.BuildRun) bool {
+	if c.status != "" && statusGroup(br) != c.status {
+		return false
+	}
+	if c.since > 0 && time.Since(br.CreationTimestamp.Time) > c.since {
+		return false
+	}
+	return true
+}
+
+// filterAndSort filters the BuildRuns by the status and since flags, sorts them and applies the
+// limit. The newest and the longest BuildRuns come first when sorting by creation and duration.
+func (c *ListCommand) filterAndSort(items []buildv1beta1.BuildRun) []buildv1beta1.BuildRun {
+	filtered := []buildv1beta1.BuildRun{}
+	for i := range items {
+		if c.matches(&items[i]) {
+			filtered = append(filtered, items[i])
 		}
-		if c.wide {
-			fmt.Fprintf(writer, columnTemplate, name, status, age, source, outputImage, buildName, elapsedTime, sourceOrigin)
-		} else {
-			fmt.Fprintf(writer, columnTemplate, name, status, age)
+	}
+
+	switch c.sortBy {
+	case sortByCreation:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[j].CreationTimestamp.Before(&filtered[i].CreationTimestamp)
+		})
+	case sortByDuration:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return elapsed(&filtered[i]) > elapsed(&filtered[j])
+		})
+	case sortByName:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Name < filtered[j].Name
+		})
+	}
+
+	if c.limit > 0 && len(filtered) > c.limit {
+		filtered = filtered[:c.limit]
+	}
+	return filtered
+}
+
+// buildRunStatus returns the reason of the succeeded condition, or unknown when not yet set.
+func buildRunStatus(br *buildv1beta1.BuildRun) string {
+	for _, condition := range br.Status.Conditions {
+		if condition.Type == buildv1beta1.Succeeded {
+			return condition.Reason
 		}
 	}
+	return string(metav1.ConditionUnknown)
+}
+
+// watchBuildRuns prints the BuildRuns as their status changes, starting from the informed resource
+// version, until interrupted. The header is printed before the first row, unless already printed.
+func (c *ListCommand) watchBuildRuns(
+	clientset buildclientset.Interface,
+	namespace string,
+	resourceVersion string,
+	headerPrinted bool,
+	ioStreams *genericclioptions.IOStreams,
+) error {
+	ctx := c.cmd.Context()
+	opts := c.filter.ListOptions()
+	opts.ResourceVersion = resourceVersion
+	w, err := clientset.ShipwrightV1beta1().BuildRuns(namespace).Watch(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	// last status printed for each BuildRun, rows are only printed when the status changes
+	printed := map[types.NamespacedName]string{}
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+			case watch.Error:
+				return k8serrors.FromObject(event.Object)
+			default:
+				continue
+			}
+			br, ok := event.Object.(*buildv1beta1.BuildRun)
+			if !ok || !c.matches(br) {
+				continue
+			}
+			key := types.NamespacedName{Namespace: br.Namespace, Name: br.Name}
+			status := buildRunStatus(br)
+			if last, found := printed[key]; found && last == status {
+				continue
+			}
+			printed[key] = status
 
-	return writer.Flush()
+			if !c.printFlags.IsHumanReadable() {
+				if err := c.printFlags.Print(br, c.noHeader, ioStreams.Out); err != nil {
+					return err
+				}
+				continue
+			}
+			if !headerPrinted && !c.noHeader {
+				fmt.Fprintln(writer, c.columnNames())
+				headerPrinted = true
+			}
+			c.printRow(writer, br)
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+		}
+	}
 }
@@ -0,0 +1,269 @@
+package buildrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestListBuildRunsFilter(t *testing.T) {
+	newBuildRun := func(namespace, name, team string) *buildv1beta1.BuildRun {
+		return &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"team": team},
+		}}
+	}
+	shpclient := shpfake.NewSimpleClientset(
+		newBuildRun("tenant-a", "br-a", "blue"),
+		newBuildRun("tenant-b", "br-b", "blue"),
+		newBuildRun("tenant-b", "br-c", "red"),
+	)
+	kclient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b"}})
+
+	tests := []struct {
+		name   string
+		args   map[string]string
+		header string
+		rows   []string
+	}{{
+		name:   "current namespace",
+		args:   map[string]string{},
+		header: "NAME",
+		rows:   []string{"br-b", "br-c"},
+	}, {
+		name:   "all namespaces",
+		args:   map[string]string{flags.AllNamespacesFlag: "true"},
+		header: "NAMESPACE",
+		rows:   []string{"tenant-a\tbr-a", "tenant-b\tbr-b", "tenant-b\tbr-c"},
+	}, {
+		name:   "all namespaces with label selector",
+		args:   map[string]string{flags.AllNamespacesFlag: "true", flags.SelectorFlag: "team=blue"},
+		header: "NAMESPACE",
+		rows:   []string{"tenant-a\tbr-a", "tenant-b\tbr-b"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			p := params.NewParamsForTest(kclient, shpclient, nil, nil, "tenant-b", nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := listCmd().(*ListCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(p, &ioStreams, nil)).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			g.Expect(lines).To(o.HaveLen(len(tt.rows) + 1))
+			g.Expect(lines[0]).To(o.HavePrefix(tt.header))
+			for i, row := range tt.rows {
+				g.Expect(lines[i+1]).To(o.HavePrefix(row))
+			}
+		})
+	}
+}
+
+func TestListBuildRunsWideImageTimestamp(t *testing.T) {
+	g := o.NewWithT(t)
+
+	shpclient := shpfake.NewSimpleClientset(
+		&buildv1beta1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "br-a"},
+			Spec: buildv1beta1.BuildRunSpec{
+				Output: &buildv1beta1.Image{Timestamp: ptr.To(buildv1beta1.OutputImageZeroTimestamp)},
+			},
+			Status: buildv1beta1.BuildRunStatus{BuildSpec: &buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{Timestamp: ptr.To(buildv1beta1.OutputImageBuildTimestamp)},
+			}},
+		},
+		&buildv1beta1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "br-b"},
+			Status: buildv1beta1.BuildRunStatus{BuildSpec: &buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{Timestamp: ptr.To("1691650396")},
+			}},
+		},
+		&buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "br-c"}},
+	)
+
+	p := params.NewParamsForTest(fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}), shpclient, nil, nil, "default", nil, nil)
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	cmd := listCmd().(*ListCommand)
+	cmd.Cmd().SetContext(context.Background())
+	g.Expect(cmd.Cmd().Flags().Set("wide", "true")).To(o.Succeed())
+
+	g.Expect(cmd.Complete(p, &ioStreams, nil)).To(o.Succeed())
+	g.Expect(cmd.Validate()).To(o.Succeed())
+	g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	g.Expect(lines).To(o.HaveLen(4))
+	g.Expect(lines[0]).To(o.HaveSuffix("IMAGE-TIMESTAMP"))
+	g.Expect(lines[1]).To(o.MatchRegexp(`^br-a\s.*\sZero$`))
+	g.Expect(lines[2]).To(o.MatchRegexp(`^br-b\s.*\s1691650396$`))
+	g.Expect(lines[3]).To(o.MatchRegexp(`^br-c\s.*\s-$`))
+}
+
+func TestListBuildRunsWatch(t *testing.T) {
+	newBuildRun := func(reason string) *buildv1beta1.BuildRun {
+		br := &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "br"}}
+		if reason != "" {
+			br.Status.Conditions = buildv1beta1.Conditions{{Type: buildv1beta1.Succeeded, Reason: reason}}
+		}
+		return br
+	}
+
+	tests := []struct {
+		name      string
+		watchFlag string
+		rows      []string
+	}{{
+		name:      "watch",
+		watchFlag: "watch",
+		rows:      []string{"NAME", "br\tPending", "br\tRunning", "br\tSucceeded"},
+	}, {
+		name:      "watch only",
+		watchFlag: "watch-only",
+		rows:      []string{"NAME", "br\tRunning", "br\tSucceeded"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			// the watcher is buffered and stopped after the events, so the command returns once all
+			// events are consumed
+			watcher := watch.NewFakeWithChanSize(10, false)
+			watcher.Modify(newBuildRun("Running"))
+			watcher.Modify(newBuildRun("Running"))
+			watcher.Modify(newBuildRun("Succeeded"))
+			watcher.Delete(newBuildRun("Succeeded"))
+			watcher.Stop()
+
+			shpclient := shpfake.NewSimpleClientset(newBuildRun("Pending"))
+			shpclient.PrependWatchReactor("buildruns", func(k8stesting.Action) (bool, watch.Interface, error) {
+				return true, watcher, nil
+			})
+			kclient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+			p := params.NewParamsForTest(kclient, shpclient, nil, nil, "default", nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := listCmd().(*ListCommand)
+			cmd.Cmd().SetContext(context.Background())
+			g.Expect(cmd.Cmd().Flags().Set(tt.watchFlag, "true")).To(o.Succeed())
+
+			g.Expect(cmd.Complete(p, &ioStreams, nil)).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			g.Expect(lines).To(o.HaveLen(len(tt.rows)))
+			for i, row := range tt.rows {
+				g.Expect(lines[i]).To(o.HavePrefix(row))
+			}
+		})
+	}
+}
+
+func TestListBuildRunsStatusAndSort(t *testing.T) {
+	now := time.Now()
+	newBuildRun := func(name, build string, age time.Duration, status corev1.ConditionStatus, reason string) *buildv1beta1.BuildRun {
+		br := &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			Labels:            map[string]string{buildv1beta1.LabelBuild: build},
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+		}}
+		if status != "" {
+			br.Status.Conditions = buildv1beta1.Conditions{{Type: buildv1beta1.Succeeded, Status: status, Reason: reason}}
+		}
+		return br
+	}
+	shpclient := shpfake.NewSimpleClientset(
+		newBuildRun("app-1", "app", 3*time.Hour, corev1.ConditionFalse, "Failed"),
+		newBuildRun("app-2", "app", 2*time.Hour, corev1.ConditionTrue, "Succeeded"),
+		newBuildRun("app-3", "app", time.Hour, corev1.ConditionFalse, "BuildRunTimeout"),
+		newBuildRun("app-4", "app", time.Minute, corev1.ConditionUnknown, "Running"),
+		newBuildRun("other-1", "other", time.Minute, corev1.ConditionFalse, "Failed"),
+		newBuildRun("other-2", "other", time.Minute, "", ""),
+	)
+	kclient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+
+	tests := []struct {
+		name    string
+		args    map[string]string
+		rows    []string
+		wantErr bool
+	}{{
+		name: "last failed run of a build",
+		args: map[string]string{"build": "app", "status": "Failed", "sort-by": "creation", "limit": "1"},
+		rows: []string{"app-3"},
+	}, {
+		name: "failed runs by name",
+		args: map[string]string{"status": "Failed", "sort-by": "name"},
+		rows: []string{"app-1", "app-3", "other-1"},
+	}, {
+		name: "pending runs",
+		args: map[string]string{"status": "Pending"},
+		rows: []string{"other-2"},
+	}, {
+		name: "created since",
+		args: map[string]string{"build": "app", "since": "90m", "sort-by": "name"},
+		rows: []string{"app-3", "app-4"},
+	}, {
+		name:    "invalid status",
+		args:    map[string]string{"status": "Unknown"},
+		wantErr: true,
+	}, {
+		name:    "invalid sort field",
+		args:    map[string]string{"sort-by": "status"},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			p := params.NewParamsForTest(kclient, shpclient, nil, nil, "default", nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := listCmd().(*ListCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(p, &ioStreams, nil)).To(o.Succeed())
+			if tt.wantErr {
+				g.Expect(cmd.Validate()).NotTo(o.Succeed())
+				return
+			}
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			g.Expect(lines).To(o.HaveLen(len(tt.rows) + 1))
+			for i, row := range tt.rows {
+				g.Expect(lines[i+1]).To(o.HavePrefix(row + "\t"))
+			}
+		})
+	}
+}
@@ -3,20 +3,28 @@ package buildrun
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/spf13/cobra"
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
 	"github.com/shipwright-io/cli/pkg/shp/params"
 	shputil "github.com/shipwright-io/cli/pkg/shp/util"
 )
@@ -27,23 +35,95 @@ type LogsCommand struct {
 
 	name string
 
-	follow   bool
-	follower *follower.Follower
+	follow       bool
+	follower     *follower.Follower
+	followOutput flags.FollowOutput // how the followed log lines are shown
+	gracePeriod  time.Duration      // time a pod may be unschedulable or failing to pull images
+
+	steps         []string // step names, or container names, to show the logs of
+	allContainers bool     // include the init containers
+	listSteps     bool     // only list the steps, with their state
+
+	timestamps bool          // include timestamps on each log line
+	since      time.Duration // only logs newer than the duration
+	tail       int64         // amount of recent log lines, negative shows all
+	limitBytes int64         // maximum amount of bytes per container, zero means no limit
 }
 
+const buildRunLogsLongDesc = `
+Shows the logs of the containers executing the BuildRun. The logs of all containers are shown by
+default, the init containers can be skipped and specific steps selected, using either the step or
+the container name. For example:
+
+	$ shp buildrun logs my-app-xyz12 --step=build-and-push
+	$ shp buildrun logs my-app-xyz12 --all-containers=false
+
+When the BuildRun is executed by a PipelineRun, the logs of the pods of all pipeline tasks are
+shown in execution order, and steps can be selected with the "task/step" notation:
+
+	$ shp buildrun logs my-app-xyz12 --step=build/build-and-push
+
+Only the most recent log lines, or the lines within a period, are retrieved with the tail and
+since flags, and each line can be prefixed with its RFC3339 timestamp:
+
+	$ shp buildrun logs my-app-xyz12 --tail=100 --timestamps
+	$ shp buildrun logs my-app-xyz12 --since=10m --follow
+
+The steps, with their state, exit code and duration, are listed with:
+
+	$ shp buildrun logs my-app-xyz12 --list-steps
+`
+
+// stepContainerPrefix is the prefix on the container names for the strategy steps.
+const stepContainerPrefix = "step-"
+
 func logsCmd() runner.SubCommand {
 	cmd := &cobra.Command{
 		Use:   "logs <name>",
 		Short: "See BuildRun log output",
+		Long:  buildRunLogsLongDesc,
 		Args:  cobra.ExactArgs(1),
 	}
 	logCommand := &LogsCommand{
 		cmd: cmd,
 	}
 	cmd.Flags().BoolVarP(&logCommand.follow, "follow", "F", logCommand.follow, "Follow the log of a buildrun until it completes or fails.")
+	cmd.Flags().StringSliceVarP(&logCommand.steps, "step", "c", nil, "Show the logs of the informed steps only, using the step or the container name")
+	cmd.Flags().BoolVar(&logCommand.allContainers, "all-containers", true, "Show the logs of the init containers as well")
+	cmd.Flags().BoolVar(&logCommand.listSteps, "list-steps", false, "List the steps with their state, exit code and duration instead of showing the logs")
+	flags.FollowOutputFlags(cmd.Flags(), &logCommand.followOutput)
+	flags.PendingGracePeriodFlag(cmd.Flags(), &logCommand.gracePeriod, follower.DefaultPendingGracePeriod)
+	cmd.Flags().BoolVar(&logCommand.timestamps, "timestamps", false, "Include the RFC3339 timestamp on each log line")
+	cmd.Flags().DurationVar(&logCommand.since, "since", 0, "Only show the logs newer than the informed duration, like 5s, 2m or 3h")
+	cmd.Flags().Int64Var(&logCommand.tail, "tail", -1, "Amount of recent log lines to show per container, all lines are shown when negative")
+	cmd.Flags().Int64Var(&logCommand.limitBytes, "limit-bytes", 0, "Maximum amount of log bytes to show per container, zero means no limit")
 	return logCommand
 }
 
+// stepCompletionFunc completes the step flag with the step names of the BuildRun pods.
+func stepCompletionFunc(p *params.Params) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		clientset, err := p.ClientSet()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		pods, err := clientset.CoreV1().Pods(p.Namespace()).List(cmd.Context(), v1.ListOptions{
+			LabelSelector: fmt.Sprintf("%v=%v", buildv1beta1.LabelBuildRun, args[0]),
+		})
+		if err != nil || len(pods.Items) == 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := []string{}
+		for i := range pods.Items {
+			names = append(names, stepNames(&pods.Items[i])...)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
 // Cmd returns cobra command object
 func (c *LogsCommand) Cmd() *cobra.Command {
 	return c.cmd
@@ -61,15 +141,40 @@ func (c *LogsCommand) Complete(params *params.Params, ioStreams *genericclioptio
 		Name:      c.name,
 	}
 	var err error
-	c.follower, err = params.NewFollower(c.Cmd().Context(), br, ioStreams)
-	return err
+	if c.follower, err = params.NewFollower(c.Cmd().Context(), br, ioStreams); err != nil {
+		return err
+	}
+	c.follower.SetOutputOptions(c.followOutput.Prefix, !c.followOutput.NoColor)
+	c.follower.SetPendingGracePeriod(c.gracePeriod)
+	return nil
 }
 
 // Validate validates data input by user
 func (c *LogsCommand) Validate() error {
+	if c.since < 0 {
+		return fmt.Errorf("since must not be negative")
+	}
+	if c.limitBytes < 0 {
+		return fmt.Errorf("limit-bytes must not be negative")
+	}
 	return nil
 }
 
+// podLogOptions returns the log request options based on the command-line flags.
+func (c *LogsCommand) podLogOptions() corev1.PodLogOptions {
+	opts := corev1.PodLogOptions{Timestamps: c.timestamps}
+	if c.since > 0 {
+		opts.SinceSeconds = ptr.To(int64(math.Ceil(c.since.Seconds())))
+	}
+	if c.tail >= 0 {
+		opts.TailLines = ptr.To(c.tail)
+	}
+	if c.limitBytes > 0 {
+		opts.LimitBytes = ptr.To(c.limitBytes)
+	}
+	return opts
+}
+
 // Run executes logs sub-command logic
 func (c *LogsCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
 	clientset, err := params.ClientSet()
@@ -81,17 +186,13 @@ func (c *LogsCommand) Run(params *params.Params, ioStreams *genericclioptions.IO
 		LabelSelector: fmt.Sprintf("%v=%v", buildv1beta1.LabelBuildRun, c.name),
 	}
 
-	// first see if pod is already done; if so, even if we have follow == true, just do the normal path;
-	// we don't employ a pod watch here since the buildrun may already be complete before 'shp buildrun logs -F'
-	// is invoked.
-	justGetLogs := false
-	var pods *corev1.PodList
+	var pods []corev1.Pod
 	err = wait.PollUntilContextTimeout(c.cmd.Context(), 1*time.Second, 10*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		if pods, err = clientset.CoreV1().Pods(params.Namespace()).List(ctx, lo); err != nil {
+		if pods, err = c.buildRunPods(ctx, params, clientset); err != nil {
 			fmt.Fprintf(ioStreams.ErrOut, "error listing Pods for BuildRun %q: %s\n", c.name, err.Error())
 			return false, nil
 		}
-		if len(pods.Items) == 0 {
+		if len(pods) == 0 {
 			fmt.Fprintf(ioStreams.ErrOut, "no builder pod found for BuildRun %q\n", c.name)
 			return false, nil
 		}
@@ -100,24 +201,41 @@ func (c *LogsCommand) Run(params *params.Params, ioStreams *genericclioptions.IO
 	if err != nil {
 		return err
 	}
-	pod := pods.Items[0]
-	phase := pod.Status.Phase
-	if phase == corev1.PodFailed || phase == corev1.PodSucceeded {
-		justGetLogs = true
+
+	// first see if pods are already done; if so, even if we have follow == true, just do the normal path;
+	// we don't employ a pod watch here since the buildrun may already be complete before 'shp buildrun logs -F'
+	// is invoked.
+	justGetLogs := c.follow && c.allPodsDone(params, pods)
+
+	if c.listSteps {
+		return printSteps(ioStreams, pods, c.allContainers)
+	}
+	// the pods of the following pipeline tasks may not exist yet, so the steps can't be validated
+	if !c.follow || justGetLogs || !isPipelineRun(pods) {
+		if err = c.validateSteps(pods); err != nil {
+			return err
+		}
 	}
 
 	if !c.follow || justGetLogs {
 		fmt.Fprintf(ioStreams.Out, "Obtaining logs for BuildRun %q\n\n", c.name)
 
 		var b strings.Builder
-		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
-			logs, err := shputil.GetPodLogs(c.cmd.Context(), clientset, pod, container.Name)
-			if err != nil {
-				return err
-			}
+		for i := range pods {
+			pod := pods[i]
+			for _, container := range c.selectContainers(&pod) {
+				logs, err := shputil.GetPodLogsWithOptions(c.cmd.Context(), clientset, pod, container.Name, c.podLogOptions())
+				if err != nil {
+					return err
+				}
 
-			fmt.Fprintf(&b, "*** Pod %q, container %q: ***\n\n", pod.Name, container.Name)
-			fmt.Fprintln(&b, logs)
+				if task := follower.PodTask(&pod); task != "" {
+					fmt.Fprintf(&b, "*** Pod %q, task %q, container %q: ***\n\n", pod.Name, task, container.Name)
+				} else {
+					fmt.Fprintf(&b, "*** Pod %q, container %q: ***\n\n", pod.Name, container.Name)
+				}
+				fmt.Fprintln(&b, logs)
+			}
 		}
 
 		fmt.Fprintln(ioStreams.Out, b.String())
@@ -125,6 +243,291 @@ func (c *LogsCommand) Run(params *params.Params, ioStreams *genericclioptions.IO
 		return nil
 
 	}
+	c.follower.SetContainerFilter(c.isSelected)
+	c.follower.SetLogOptions(c.podLogOptions())
 	_, err = c.follower.Start(lo)
 	return err
 }
+
+// isPipelineRun returns true when the pods belong to a PipelineRun executor, one pod per task.
+func isPipelineRun(pods []corev1.Pod) bool {
+	for i := range pods {
+		if follower.PodTask(&pods[i]) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRunPods returns the pods of the BuildRun in execution order. The pods are found through the
+// BuildRun executor, for a PipelineRun its TaskRuns are listed and the pods ordered by pipeline task.
+// Until the executor is known, the pods are listed by the BuildRun label.
+func (c *LogsCommand) buildRunPods(ctx context.Context, params *params.Params, clientset kubernetes.Interface) ([]corev1.Pod, error) {
+	shpClientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return nil, err
+	}
+	br, err := shpClientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).Get(ctx, c.name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	executorKind, executorName := executorForBuildRun(br)
+	switch executorKind {
+	case "TaskRun":
+		podList, err := clientset.CoreV1().Pods(params.Namespace()).List(ctx, v1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", follower.TaskRunLabel, executorName),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return podList.Items, nil
+	case "PipelineRun":
+		return pipelineRunPods(ctx, params, clientset, executorName)
+	default:
+		podList, err := clientset.CoreV1().Pods(params.Namespace()).List(ctx, v1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", buildv1beta1.LabelBuildRun, c.name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		sortPods(podList.Items)
+		return podList.Items, nil
+	}
+}
+
+// pipelineRunPods returns the pods of the TaskRuns created by the PipelineRun, ordered by the
+// position of their pipeline task on the PipelineRun.
+func pipelineRunPods(ctx context.Context, params *params.Params, clientset kubernetes.Interface, name string) ([]corev1.Pod, error) {
+	dynamicClient, err := params.DynamicClientSet()
+	if err != nil {
+		return nil, err
+	}
+	namespace := params.Namespace()
+
+	pipelineRun, err := dynamicClient.Resource(pipelineRunGVR).Namespace(namespace).Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PipelineRun %q: %w", name, err)
+	}
+	taskRuns, err := dynamicClient.Resource(taskRunGVR).Namespace(namespace).List(ctx, v1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", follower.PipelineRunLabel, name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TaskRuns for PipelineRun %q: %w", name, err)
+	}
+
+	pods := []corev1.Pod{}
+	for i := range taskRuns.Items {
+		taskRun := &taskRuns.Items[i]
+		pod, err := resolvePodForTaskRun(ctx, clientset, namespace, taskRun.GetName(), getPodName(taskRun))
+		if err != nil {
+			return nil, err
+		}
+		if pod != nil {
+			pods = append(pods, *pod)
+		}
+	}
+
+	order := pipelineTaskOrder(pipelineRun)
+	sort.SliceStable(pods, func(i, j int) bool {
+		ti, tj := follower.PodTask(&pods[i]), follower.PodTask(&pods[j])
+		oi, oki := order[ti]
+		oj, okj := order[tj]
+		switch {
+		case oki && okj:
+			return oi < oj
+		case oki != okj:
+			return oki
+		default:
+			return ti < tj
+		}
+	})
+	return pods, nil
+}
+
+// pipelineTaskOrder returns the position of each pipeline task, followed by the finally tasks, on
+// the pipeline spec resolved by Tekton, or on the pipeline spec embedded in the PipelineRun.
+func pipelineTaskOrder(pipelineRun *unstructured.Unstructured) map[string]int {
+	order := map[string]int{}
+	for _, path := range [][]string{{"status", "pipelineSpec"}, {"spec", "pipelineSpec"}} {
+		for _, field := range []string{"tasks", "finally"} {
+			tasks, _, _ := unstructured.NestedSlice(pipelineRun.Object, append(path, field)...)
+			for _, task := range tasks {
+				taskMap, ok := task.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if name, ok := taskMap["name"].(string); ok {
+					order[name] = len(order)
+				}
+			}
+		}
+		if len(order) > 0 {
+			break
+		}
+	}
+	return order
+}
+
+// sortPods orders the pods by creation time, pods of a PipelineRun created at the same time are
+// ordered by task name. Used while the BuildRun executor is not known yet.
+func sortPods(pods []corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		ti, tj := pods[i].CreationTimestamp, pods[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return follower.PodTask(&pods[i]) < follower.PodTask(&pods[j])
+	})
+}
+
+// allPodsDone returns true when all pods are completed, for a PipelineRun executor the BuildRun
+// must be done as well, since the pods of the following tasks may not exist yet.
+func (c *LogsCommand) allPodsDone(params *params.Params, pods []corev1.Pod) bool {
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodFailed && pod.Status.Phase != corev1.PodSucceeded {
+			return false
+		}
+	}
+	if !isPipelineRun(pods) {
+		return true
+	}
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return false
+	}
+	br, err := clientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).Get(c.cmd.Context(), c.name, v1.GetOptions{})
+	return err == nil && br.IsDone()
+}
+
+// isSelected returns true when the pod container is selected by the step and all-containers flags,
+// steps are informed by step name, container name, or "task/step" for pods of a PipelineRun.
+func (c *LogsCommand) isSelected(pod *corev1.Pod, name string) bool {
+	if len(c.steps) == 0 {
+		if c.allContainers {
+			return true
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, step := range c.steps {
+		if task, taskStep, found := strings.Cut(step, "/"); found {
+			if task != follower.PodTask(pod) {
+				continue
+			}
+			step = taskStep
+		}
+		if name == step || name == stepContainerPrefix+step {
+			return true
+		}
+	}
+	return false
+}
+
+// selectContainers returns the pod containers selected by the step and all-containers flags, in
+// execution order.
+func (c *LogsCommand) selectContainers(pod *corev1.Pod) []corev1.Container {
+	selected := []corev1.Container{}
+	for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		if c.isSelected(pod, container.Name) {
+			selected = append(selected, container)
+		}
+	}
+	return selected
+}
+
+// validateSteps makes sure each informed step matches a container on at least one of the pods.
+func (c *LogsCommand) validateSteps(pods []corev1.Pod) error {
+	for _, step := range c.steps {
+		found := false
+		names := []string{}
+		for i := range pods {
+			probe := &LogsCommand{steps: []string{step}}
+			for _, container := range append(pods[i].Spec.InitContainers, pods[i].Spec.Containers...) {
+				found = found || probe.isSelected(&pods[i], container.Name)
+			}
+			names = append(names, stepNames(&pods[i])...)
+		}
+		if found {
+			continue
+		}
+		if len(pods) == 1 {
+			return fmt.Errorf("step %q not found in pod %q, available steps: %s",
+				step, pods[0].GetName(), strings.Join(names, ", "))
+		}
+		return fmt.Errorf("step %q not found in the pods of BuildRun %q, available steps: %s",
+			step, c.name, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// stepNames returns the step names of the pod, without the container name prefix, preceded by the
+// task name for pods of a PipelineRun.
+func stepNames(pod *corev1.Pod) []string {
+	names := []string{}
+	for _, container := range pod.Spec.Containers {
+		names = append(names, follower.StepName(pod, container.Name))
+	}
+	return names
+}
+
+// printSteps writes a table with the state, exit code and duration of each pod container, with the
+// task column for pods of a PipelineRun.
+func printSteps(ioStreams *genericclioptions.IOStreams, pods []corev1.Pod, allContainers bool) error {
+	pipeline := isPipelineRun(pods)
+	writer := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
+	if pipeline {
+		fmt.Fprint(writer, "TASK\t")
+	}
+	fmt.Fprintln(writer, "STEP\tCONTAINER\tSTATE\tEXIT-CODE\tDURATION")
+	for i := range pods {
+		pod := &pods[i]
+		containers := pod.Spec.Containers
+		if allContainers {
+			containers = append(append([]corev1.Container{}, pod.Spec.InitContainers...), containers...)
+		}
+		byName := map[string]corev1.ContainerStatus{}
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			byName[status.Name] = status
+		}
+
+		for _, container := range containers {
+			if pipeline {
+				fmt.Fprintf(writer, "%s\t", follower.PodTask(pod))
+			}
+			state, exitCode, elapsed := containerState(byName[container.Name])
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+				strings.TrimPrefix(container.Name, stepContainerPrefix),
+				container.Name,
+				state,
+				exitCode,
+				elapsed,
+			)
+		}
+	}
+	return writer.Flush()
+}
+
+// containerState returns the state, exit code and duration of a container, using "-" for the
+// attributes not yet known.
+func containerState(status corev1.ContainerStatus) (string, string, string) {
+	switch {
+	case status.State.Terminated != nil:
+		terminated := status.State.Terminated
+		elapsed := "-"
+		if !terminated.StartedAt.IsZero() && !terminated.FinishedAt.IsZero() {
+			elapsed = terminated.FinishedAt.Sub(terminated.StartedAt.Time).String()
+		}
+		return fmt.Sprintf("Terminated (%s)", terminated.Reason), fmt.Sprint(terminated.ExitCode), elapsed
+	case status.State.Running != nil:
+		return "Running", "-", duration.ShortHumanDuration(time.Since(status.State.Running.StartedAt.Time))
+	case status.State.Waiting != nil:
+		return fmt.Sprintf("Waiting (%s)", status.State.Waiting.Reason), "-", "-"
+	default:
+		return "Unknown", "-", "-"
+	}
+}
@@ -2,10 +2,15 @@ package buildrun
 
 import (
 	"bytes"
+	"context"
 	"strings"
 	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
 
 	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
 	fakekubetesting "k8s.io/client-go/testing"
@@ -15,12 +20,21 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 
 	"github.com/spf13/cobra"
 )
 
+// newTestBuildRun returns a BuildRun whose executor is not known yet.
+func newTestBuildRun(name string) *v1beta1.BuildRun {
+	return &v1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name}}
+}
+
 func TestStreamBuildLogs(t *testing.T) {
 	name := "test-obj"
 	pod := &corev1.Pod{}
@@ -43,8 +57,9 @@ func TestStreamBuildLogs(t *testing.T) {
 	}
 
 	clientset := fake.NewSimpleClientset(pod)
+	shpclientset := shpfake.NewSimpleClientset(newTestBuildRun(name))
 	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
-	param := params.NewParamsForTest(clientset, nil, nil, nil, metav1.NamespaceDefault, nil, nil)
+	param := params.NewParamsForTest(clientset, shpclientset, nil, nil, metav1.NamespaceDefault, nil, nil)
 	err := cmd.Run(param, &ioStreams)
 	if err != nil {
 		t.Fatalf("%s", err.Error())
@@ -228,12 +243,10 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 			continue
 		}
 
+		// the command runs until the follower is stopped, at the end of the test case
+		done := make(chan error, 1)
 		go func() {
-			err := cmd.Run(param, &ioStreams)
-			if err != nil {
-				t.Errorf("%s", err.Error())
-			}
-
+			done <- cmd.Run(param, &ioStreams)
 		}()
 
 		if !test.noPodYet {
@@ -244,6 +257,15 @@ func TestStreamBuildRunFollowLogs(t *testing.T) {
 			cmd.follower.OnNoPodEventsYet(nil)
 		}
 		checkLog(test.name, test.logText, cmd, out, t)
+		cmd.follower.Stop()
+		err := <-done
+		switch {
+		case test.noPodYet && err == nil:
+			// without pods the command gives up waiting for them
+			t.Errorf("test %s: expected error waiting for the pods", test.name)
+		case !test.noPodYet && err != nil:
+			t.Errorf("test %s: %s", test.name, err.Error())
+		}
 	}
 
 }
@@ -256,3 +278,296 @@ func checkLog(name, text string, cmd *LogsCommand, out *bytes.Buffer, t *testing
 		t.Errorf("test %s: unexpected output: %s", name, out.String())
 	}
 }
+
+func TestBuildRunLogsSteps(t *testing.T) {
+	name := "test-br"
+	started := metav1.NewTime(time.Now().Add(-time.Minute))
+	finished := metav1.NewTime(started.Add(30 * time.Second))
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-pod",
+			Labels:    map[string]string{v1beta1.LabelBuildRun: name},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "prepare"}},
+			Containers:     []corev1.Container{{Name: "step-source-default"}, {Name: "step-build-and-push"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-source-default",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason: "Completed", StartedAt: started, FinishedAt: finished,
+				}},
+			}, {
+				Name: "step-build-and-push",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					Reason: "Error", ExitCode: 1, StartedAt: started, FinishedAt: finished,
+				}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		args       map[string]string
+		contains   []string
+		notContain []string
+		err        string
+	}{{
+		name:     "all containers",
+		contains: []string{`container "prepare"`, `container "step-source-default"`, `container "step-build-and-push"`},
+	}, {
+		name:       "without init containers",
+		args:       map[string]string{"all-containers": "false"},
+		contains:   []string{`container "step-source-default"`, `container "step-build-and-push"`},
+		notContain: []string{`container "prepare"`},
+	}, {
+		name:       "step name",
+		args:       map[string]string{"step": "build-and-push"},
+		contains:   []string{`container "step-build-and-push"`},
+		notContain: []string{`container "prepare"`, `container "step-source-default"`},
+	}, {
+		name:       "container names",
+		args:       map[string]string{"step": "prepare,step-source-default"},
+		contains:   []string{`container "prepare"`, `container "step-source-default"`},
+		notContain: []string{`container "step-build-and-push"`},
+	}, {
+		name: "unknown step",
+		args: map[string]string{"step": "push"},
+		err:  `step "push" not found in pod "test-pod", available steps: source-default, build-and-push`,
+	}, {
+		name:       "list steps",
+		args:       map[string]string{"list-steps": "true", "all-containers": "false"},
+		contains:   []string{"STEP", "build-and-push\tstep-build-and-push\tTerminated (Error)\t1", "30s"},
+		notContain: []string{"prepare", "fake logs"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			param := params.NewParamsForTest(fake.NewSimpleClientset(pod), shpfake.NewSimpleClientset(newTestBuildRun(name)),
+				nil, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := logsCmd().(*LogsCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(param, &ioStreams, []string{name})).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			err := cmd.Run(param, &ioStreams)
+			if tt.err != "" {
+				g.Expect(err).To(o.MatchError(tt.err))
+				return
+			}
+			g.Expect(err).NotTo(o.HaveOccurred())
+			for _, s := range tt.contains {
+				g.Expect(out.String()).To(o.ContainSubstring(s))
+			}
+			for _, s := range tt.notContain {
+				g.Expect(out.String()).NotTo(o.ContainSubstring(s))
+			}
+		})
+	}
+}
+
+func TestBuildRunLogsOptions(t *testing.T) {
+	name := "test-br"
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-pod",
+			Labels:    map[string]string{v1beta1.LabelBuildRun: name},
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "step-build-and-push"}}},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+
+	tests := []struct {
+		name     string
+		args     map[string]string
+		expected corev1.PodLogOptions
+		err      bool
+	}{{
+		name:     "defaults",
+		expected: corev1.PodLogOptions{Container: "step-build-and-push"},
+	}, {
+		name: "all options",
+		args: map[string]string{"timestamps": "true", "since": "90s", "tail": "10", "limit-bytes": "1024"},
+		expected: corev1.PodLogOptions{
+			Container:    "step-build-and-push",
+			Timestamps:   true,
+			SinceSeconds: ptr.To[int64](90),
+			TailLines:    ptr.To[int64](10),
+			LimitBytes:   ptr.To[int64](1024),
+		},
+	}, {
+		name:     "since rounded up to seconds",
+		args:     map[string]string{"since": "1500ms"},
+		expected: corev1.PodLogOptions{Container: "step-build-and-push", SinceSeconds: ptr.To[int64](2)},
+	}, {
+		name: "negative limit bytes",
+		args: map[string]string{"limit-bytes": "-1"},
+		err:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			clientset := fake.NewSimpleClientset(pod)
+			param := params.NewParamsForTest(clientset, shpfake.NewSimpleClientset(newTestBuildRun(name)),
+				nil, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+			cmd := logsCmd().(*LogsCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(param, &ioStreams, []string{name})).To(o.Succeed())
+			if tt.err {
+				g.Expect(cmd.Validate()).NotTo(o.Succeed())
+				return
+			}
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			g.Expect(cmd.Run(param, &ioStreams)).To(o.Succeed())
+
+			var opts []*corev1.PodLogOptions
+			for _, action := range clientset.Actions() {
+				if action.GetSubresource() == "log" {
+					opts = append(opts, action.(fakekubetesting.GenericAction).GetValue().(*corev1.PodLogOptions))
+				}
+			}
+			g.Expect(opts).To(o.HaveLen(1))
+			g.Expect(*opts[0]).To(o.Equal(tt.expected))
+		})
+	}
+}
+
+func TestBuildRunLogsPipelineRun(t *testing.T) {
+	name := "test-br"
+	pipelineRunName := "test-br-pr"
+	created := metav1.NewTime(time.Now().Add(-time.Minute))
+	newPod := func(podName, task string, created metav1.Time, containers ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         metav1.NamespaceDefault,
+				Name:              podName,
+				CreationTimestamp: created,
+				Labels: map[string]string{
+					v1beta1.LabelBuildRun:      name,
+					follower.PipelineTaskLabel: task,
+					follower.TaskRunLabel:      pipelineRunName + "-" + task,
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		}
+		for _, container := range containers {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+		}
+		return pod
+	}
+	newTaskRun := func(task string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "tekton.dev/v1",
+			"kind":       "TaskRun",
+			"metadata": map[string]interface{}{
+				"name":      pipelineRunName + "-" + task,
+				"namespace": metav1.NamespaceDefault,
+				"labels":    map[string]interface{}{follower.PipelineRunLabel: pipelineRunName},
+			},
+			"status": map[string]interface{}{"podName": "test-br-" + task + "-pod"},
+		}}
+	}
+	// the pods are created out of execution order, the pipeline tasks define the order
+	push := newPod("test-br-push-pod", "push", created, "step-push")
+	build := newPod("test-br-build-pod", "build", metav1.NewTime(created.Add(time.Second)), "step-source-default", "step-build")
+	// a pod of another BuildRun, which must not be shown
+	other := newPod("other-br-pod", "build", created, "step-build")
+	other.Labels = map[string]string{follower.TaskRunLabel: "other-br-build"}
+
+	br := newTestBuildRun(name)
+	br.Status.Executor = &v1beta1.BuildExecutor{Kind: "PipelineRun", Name: pipelineRunName}
+	pipelineRun := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tekton.dev/v1",
+		"kind":       "PipelineRun",
+		"metadata":   map[string]interface{}{"name": pipelineRunName, "namespace": metav1.NamespaceDefault},
+		"spec": map[string]interface{}{
+			"pipelineSpec": map[string]interface{}{
+				"tasks": []interface{}{
+					map[string]interface{}{"name": "build"},
+					map[string]interface{}{"name": "push"},
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name       string
+		args       map[string]string
+		contains   []string
+		notContain []string
+		err        string
+	}{{
+		name: "all tasks",
+		contains: []string{
+			`*** Pod "test-br-build-pod", task "build", container "step-source-default": ***`,
+			`*** Pod "test-br-build-pod", task "build", container "step-build": ***`,
+			`*** Pod "test-br-push-pod", task "push", container "step-push": ***`,
+		},
+	}, {
+		name:       "task and step name",
+		args:       map[string]string{"step": "push/push"},
+		contains:   []string{`task "push", container "step-push"`},
+		notContain: []string{`task "build"`},
+	}, {
+		name: "unknown step",
+		args: map[string]string{"step": "build/push"},
+		err:  `step "build/push" not found in the pods of BuildRun "test-br", available steps: build/source-default, build/build, push/push`,
+	}, {
+		name:     "list steps",
+		args:     map[string]string{"list-steps": "true"},
+		contains: []string{"TASK", "build\tsource-default\tstep-source-default", "push\tpush\t\tstep-push"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, pipelineRun, newTaskRun("push"), newTaskRun("build"))
+			param := params.NewParamsForTest(fake.NewSimpleClientset(push, build, other), shpfake.NewSimpleClientset(br),
+				dynamicClient, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := logsCmd().(*LogsCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(param, &ioStreams, []string{name})).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			err := cmd.Run(param, &ioStreams)
+			if tt.err != "" {
+				g.Expect(err).To(o.MatchError(tt.err))
+				return
+			}
+			g.Expect(err).NotTo(o.HaveOccurred())
+			for _, s := range tt.contains {
+				g.Expect(out.String()).To(o.ContainSubstring(s))
+			}
+			for _, s := range tt.notContain {
+				g.Expect(out.String()).NotTo(o.ContainSubstring(s))
+			}
+			g.Expect(out.String()).NotTo(o.ContainSubstring("other-br-pod"))
+			if tt.name == "all tasks" {
+				g.Expect(strings.Index(out.String(), "test-br-build-pod")).
+					To(o.BeNumerically("<", strings.Index(out.String(), "test-br-push-pod")))
+			}
+		})
+	}
+}
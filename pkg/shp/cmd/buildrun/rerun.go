@@ -0,0 +1,382 @@
+package buildrun
+
+import (
+	"fmt"
+	"time"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/follower"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+	shputil "github.com/shipwright-io/cli/pkg/shp/util"
+)
+
+// RerunCommand represents the buildrun's rerun subcommand, creating a new BuildRun with the same
+// inputs as a previous one.
+type RerunCommand struct {
+	cmd *cobra.Command // cobra command instance
+
+	name         string                     // original buildrun name
+	namespace    string                     // namespace of the original buildrun
+	buildRunSpec *buildv1beta1.BuildRunSpec // stores command-line flags
+	pinRevision  bool                       // use the commit resolved by the original buildrun
+
+	follow        bool // flag to tail pod logs
+	follower      *follower.Follower
+	followerReady chan bool
+	followOutput  flags.FollowOutput // how the followed log lines are shown
+	gracePeriod   time.Duration      // time a pod may be unschedulable or failing to pull images
+}
+
+const buildRunRerunLongDesc = `
+Creates a new BuildRun with the same inputs as a previous one, including parameter values,
+environment variables, output overrides, embedded Build specification, node selector and service
+account. The flags informed on the command-line override the original values. For example:
+
+	$ shp buildrun rerun my-app-xyz12 --follow
+	$ shp buildrun rerun my-app-xyz12 --env="DEBUG=true"
+
+The source can be pinned to the Git commit resolved by the original BuildRun, in this case the
+Build specification recorded on the original BuildRun is embedded on the new one, with the output,
+parameter, environment and timeout overrides folded into it:
+
+	$ shp buildrun rerun my-app-xyz12 --pin-revision
+`
+
+// Cmd returns cobra.Command object of the rerun subcommand.
+func (c *RerunCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete picks the original BuildRun name from arguments, and instantiates the follower.
+func (c *RerunCommand) Complete(params *params.Params, ioStreams *genericclioptions.IOStreams, args []string) error {
+	switch len(args) {
+	case 1:
+		c.name = args[0]
+	default:
+		return fmt.Errorf("wrong amount of arguments, expected only one")
+	}
+
+	c.namespace = params.Namespace()
+
+	if c.follow {
+		var err error
+		// provide empty build run name; will be set in Run()
+		c.follower, err = params.NewFollower(c.cmd.Context(), types.NamespacedName{}, ioStreams)
+		if err != nil {
+			return err
+		}
+		c.follower.SetOutputOptions(c.followOutput.Prefix, !c.followOutput.NoColor)
+		c.follower.SetPendingGracePeriod(c.gracePeriod)
+		c.followerReady = make(chan bool, 1)
+	}
+	return nil
+}
+
+// Validate makes sure a name is informed.
+func (c *RerunCommand) Validate() error {
+	if c.name == "" {
+		return fmt.Errorf("name is not informed")
+	}
+	return nil
+}
+
+// FollowerReady blocks until the any log following connections are established in the Run call.
+func (c *RerunCommand) FollowerReady() bool {
+	if !c.follow {
+		return false
+	}
+	_, closed := <-c.followerReady
+	return !closed
+}
+
+// Run creates the new BuildRun based on the original one, and follows its logs when requested.
+func (c *RerunCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	ctx := c.cmd.Context()
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+
+	original, err := clientset.ShipwrightV1beta1().BuildRuns(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// only the flag values are sanitized, the original spec is kept as is, including overrides
+	// carrying only empty or false values
+	flags.SanitizeBuildRunSpec(c.buildRunSpec)
+	spec := original.Spec.DeepCopy()
+	// the original may have been canceled, the new BuildRun must start regardless
+	spec.State = nil
+	mergeBuildRunSpec(c.cmd.Flags(), spec, c.buildRunSpec)
+	if c.pinRevision {
+		if err = c.pinSourceRevision(params, original, spec); err != nil {
+			return err
+		}
+	}
+	if c.cmd.Flags().Changed(flags.VolumeFlag) {
+		if err = shputil.ValidateBuildRunVolumes(ctx, clientset, c.namespace, spec); err != nil {
+			return err
+		}
+	}
+
+	generateName := original.GetGenerateName()
+	if buildName := original.Spec.BuildName(); buildName != "" {
+		generateName = fmt.Sprintf("%s-", buildName)
+	} else if generateName == "" {
+		generateName = fmt.Sprintf("%s-", original.GetName())
+	}
+	br := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: generateName},
+		Spec:       *spec,
+	}
+	// the Build name is lost when the specification is embedded, the label keeps the reference
+	if buildName := originalBuildName(original); buildName != "" && spec.Build.Name == nil {
+		br.Labels = map[string]string{buildv1beta1.LabelBuild: buildName}
+	}
+	br, err = clientset.ShipwrightV1beta1().BuildRuns(c.namespace).Create(ctx, br, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !c.follow {
+		fmt.Fprintf(ioStreams.Out, "BuildRun created %q as a rerun of %q\n", br.GetName(), c.name)
+		return nil
+	}
+
+	c.follower.SetBuildRunName(types.NamespacedName{Namespace: c.namespace, Name: br.GetName()})
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", buildv1beta1.LabelBuildRun, br.GetName())}
+	if err = c.follower.Connect(listOpts); err != nil {
+		return err
+	}
+	close(c.followerReady)
+	_, err = c.follower.WaitForCompletion()
+	return err
+}
+
+// originalBuildName returns the name of the Build the original BuildRun refers to, either directly
+// or through the label set when the Build specification is embedded.
+func originalBuildName(original *buildv1beta1.BuildRun) string {
+	if buildName := original.Spec.BuildName(); buildName != "" {
+		return buildName
+	}
+	return original.GetLabels()[buildv1beta1.LabelBuild]
+}
+
+// pinSourceRevision embeds the Build specification recorded on the original BuildRun, with the Git
+// revision set to the commit resolved by the original BuildRun. The build controller doesn't allow
+// output, parameter, environment and timeout overrides together with an embedded specification, so
+// these are folded into it.
+func (c *RerunCommand) pinSourceRevision(
+	params *params.Params,
+	original *buildv1beta1.BuildRun,
+	spec *buildv1beta1.BuildRunSpec,
+) error {
+	if original.Status.Source == nil || original.Status.Source.Git == nil ||
+		original.Status.Source.Git.CommitSha == "" {
+		return fmt.Errorf("BuildRun %q has no resolved Git commit to pin the revision to", c.name)
+	}
+
+	buildSpec := original.Status.BuildSpec.DeepCopy()
+	if buildSpec == nil {
+		buildSpec = original.Spec.Build.Spec.DeepCopy()
+	}
+	if buildSpec == nil {
+		clientset, err := params.ShipwrightClientSet()
+		if err != nil {
+			return err
+		}
+		build, err := clientset.ShipwrightV1beta1().Builds(c.namespace).
+			Get(c.cmd.Context(), originalBuildName(original), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		buildSpec = build.Spec.DeepCopy()
+	}
+	if buildSpec.Source == nil || buildSpec.Source.Git == nil {
+		return fmt.Errorf("BuildRun %q source is not a Git repository", c.name)
+	}
+
+	commitSha := original.Status.Source.Git.CommitSha
+	buildSpec.Source.Git.Revision = &commitSha
+	foldBuildRunOverrides(buildSpec, spec)
+	spec.Build = buildv1beta1.ReferencedBuild{Spec: buildSpec}
+	return nil
+}
+
+// foldBuildRunOverrides moves the output, parameter, environment and timeout overrides of the
+// BuildRun spec onto the Build spec, the attributes informed on the BuildRun take precedence.
+func foldBuildRunOverrides(buildSpec *buildv1beta1.BuildSpec, spec *buildv1beta1.BuildRunSpec) {
+	if output := spec.Output; output != nil {
+		if output.Image != "" {
+			buildSpec.Output.Image = output.Image
+		}
+		if output.PushSecret != nil {
+			buildSpec.Output.PushSecret = output.PushSecret
+		}
+		if output.Insecure != nil {
+			buildSpec.Output.Insecure = output.Insecure
+		}
+		if output.Timestamp != nil {
+			buildSpec.Output.Timestamp = output.Timestamp
+		}
+		if output.VulnerabilityScan != nil {
+			buildSpec.Output.VulnerabilityScan = output.VulnerabilityScan
+		}
+		if len(output.Labels) > 0 {
+			buildSpec.Output.Labels = flags.MergeMap(buildSpec.Output.Labels, output.Labels)
+		}
+		if len(output.Annotations) > 0 {
+			buildSpec.Output.Annotations = flags.MergeMap(buildSpec.Output.Annotations, output.Annotations)
+		}
+		spec.Output = nil
+	}
+	for _, pv := range spec.ParamValues {
+		flags.UpsertParam(&buildSpec.ParamValues, pv)
+	}
+	spec.ParamValues = nil
+	for _, env := range spec.Env {
+		flags.UpsertEnv(&buildSpec.Env, env)
+	}
+	spec.Env = nil
+	if spec.Timeout != nil {
+		buildSpec.Timeout = spec.Timeout
+		spec.Timeout = nil
+	}
+}
+
+// mergeBuildRunSpec copies onto dst the attributes of src which have been explicitly informed on
+// the command-line, list and map attributes are merged with the existing entries. The src is
+// sanitized, thus empty inner structures may be nil.
+func mergeBuildRunSpec(fs *pflag.FlagSet, dst, src *buildv1beta1.BuildRunSpec) {
+	changed := func(names ...string) bool {
+		for _, name := range names {
+			if fs.Changed(name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	srcOutput := &buildv1beta1.Image{}
+	if src.Output != nil {
+		srcOutput = src.Output
+	}
+	srcRetention := &buildv1beta1.BuildRunRetention{}
+	if src.Retention != nil {
+		srcRetention = src.Retention
+	}
+
+	if changed(flags.BuildrefNameFlag) {
+		dst.Build = buildv1beta1.ReferencedBuild{Name: src.Build.Name}
+	}
+	if changed(flags.ServiceAccountNameFlag) {
+		dst.ServiceAccount = src.ServiceAccount
+	}
+	if changed(flags.TimeoutFlag) {
+		dst.Timeout = src.Timeout
+	}
+
+	// output image
+	if changed(flags.OutputImageFlag, flags.OutputImagePushSecretFlag, flags.OutputCredentialsSecretFlag,
+		flags.OutputInsecureFlag, flags.OutputImageLabelsFlag, flags.OutputImageAnnotationsFlag, flags.OutputTimestampFlag,
+		flags.OutputVulnScanFlag, flags.OutputVulnFailOnFindingFlag, flags.OutputVulnIgnoreIDFlag,
+		flags.OutputVulnIgnoreSeverityFlag, flags.OutputVulnIgnoreUnfixedFlag) &&
+		dst.Output == nil {
+		dst.Output = &buildv1beta1.Image{}
+	}
+	if changed(flags.OutputImageFlag) {
+		dst.Output.Image = srcOutput.Image
+	}
+	if changed(flags.OutputImagePushSecretFlag, flags.OutputCredentialsSecretFlag) {
+		dst.Output.PushSecret = srcOutput.PushSecret
+	}
+	if changed(flags.OutputInsecureFlag) {
+		dst.Output.Insecure = srcOutput.Insecure
+	}
+	if changed(flags.OutputTimestampFlag) {
+		dst.Output.Timestamp = srcOutput.Timestamp
+	}
+	if changed(flags.OutputImageLabelsFlag) {
+		dst.Output.Labels = flags.MergeMap(dst.Output.Labels, srcOutput.Labels)
+	}
+	if changed(flags.OutputImageAnnotationsFlag) {
+		dst.Output.Annotations = flags.MergeMap(dst.Output.Annotations, srcOutput.Annotations)
+	}
+	if dst.Output != nil {
+		dst.Output.VulnerabilityScan = flags.MergeVulnerabilityScan(fs, dst.Output.VulnerabilityScan, srcOutput.VulnerabilityScan)
+	}
+
+	// environment variables and parameters are replaced by name, or appended
+	if changed(flags.EnvFlag) {
+		for _, env := range src.Env {
+			flags.UpsertEnv(&dst.Env, env)
+		}
+	}
+	if changed(flags.ParamValueFlag) {
+		for _, pv := range src.ParamValues {
+			flags.UpsertParam(&dst.ParamValues, pv)
+		}
+	}
+
+	// retention
+	if changed(flags.RetentionTTLAfterFailedFlag, flags.RetentionTTLAfterSucceededFlag) && dst.Retention == nil {
+		dst.Retention = &buildv1beta1.BuildRunRetention{}
+	}
+	if changed(flags.RetentionTTLAfterFailedFlag) {
+		dst.Retention.TTLAfterFailed = srcRetention.TTLAfterFailed
+	}
+	if changed(flags.RetentionTTLAfterSucceededFlag) {
+		dst.Retention.TTLAfterSucceeded = srcRetention.TTLAfterSucceeded
+	}
+
+	// strategy volumes are replaced by name, or appended
+	if changed(flags.VolumeFlag) {
+		for _, volume := range src.Volumes {
+			flags.UpsertVolume(&dst.Volumes, volume)
+		}
+	}
+
+	// scheduling
+	if changed(flags.NodeSelectorFlag) {
+		dst.NodeSelector = flags.MergeMap(dst.NodeSelector, src.NodeSelector)
+	}
+	if changed(flags.TolerationFlag) {
+		for _, toleration := range src.Tolerations {
+			flags.UpsertToleration(&dst.Tolerations, toleration)
+		}
+	}
+	if changed(flags.SchedulerNameFlag) {
+		dst.SchedulerName = src.SchedulerName
+	}
+	if changed(flags.RuntimeClassNameFlag) {
+		dst.RuntimeClassName = src.RuntimeClassName
+	}
+}
+
+// rerunCmd instantiates the "buildrun rerun" subcommand using common BuildRun flags.
+func rerunCmd() runner.SubCommand {
+	cmd := &cobra.Command{
+		Use:   "rerun <name> [flags]",
+		Short: "Creates a new BuildRun with the same inputs as a previous one.",
+		Long:  buildRunRerunLongDesc,
+	}
+	c := &RerunCommand{
+		cmd:          cmd,
+		buildRunSpec: flags.BuildRunSpecFromFlags(cmd.Flags()),
+	}
+	cmd.Flags().BoolVar(&c.pinRevision, "pin-revision", false, "Pin the source to the Git commit resolved by the original BuildRun")
+	flags.FollowFlag(cmd.Flags(), &c.follow)
+	flags.FollowOutputFlags(cmd.Flags(), &c.followOutput)
+	flags.PendingGracePeriodFlag(cmd.Flags(), &c.gracePeriod, follower.DefaultPendingGracePeriod)
+	return c
+}
@@ -0,0 +1,180 @@
+package buildrun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestRerunBuildRun(t *testing.T) {
+	original := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app-abc12"},
+		Spec: buildv1beta1.BuildRunSpec{
+			Build:          buildv1beta1.ReferencedBuild{Name: ptr.To("app")},
+			ServiceAccount: ptr.To("builder"),
+			ParamValues: []buildv1beta1.ParamValue{{
+				Name:        "go-version",
+				SingleValue: &buildv1beta1.SingleValue{Value: ptr.To("1.22")},
+			}},
+			// an output override without image, it must be kept as is
+			Output: &buildv1beta1.Image{
+				Labels:      map[string]string{"team": "blue"},
+				Annotations: map[string]string{"owner": "shipwright"},
+				Insecure:    ptr.To(false),
+			},
+			Env:          []corev1.EnvVar{{Name: "DEBUG", Value: "false"}, {Name: "CGO_ENABLED", Value: "0"}},
+			NodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+			State: ptr.To(buildv1beta1.BuildRunRequestedState(buildv1beta1.BuildRunStateCancel)),
+		},
+		Status: buildv1beta1.BuildRunStatus{
+			Source: &buildv1beta1.SourceResult{
+				Git: &buildv1beta1.GitSourceResult{CommitSha: "0123abc"},
+			},
+			BuildSpec: &buildv1beta1.BuildSpec{
+				Source: &buildv1beta1.Source{
+					Type: buildv1beta1.GitType,
+					Git:  &buildv1beta1.Git{URL: "https://github.com/shipwright-io/sample-go", Revision: ptr.To("main")},
+				},
+				Strategy: buildv1beta1.Strategy{Name: "buildpacks-v3"},
+				Output:   buildv1beta1.Image{Image: "registry/app:latest"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		args   map[string]string
+		verify func(g *o.WithT, br *buildv1beta1.BuildRun)
+	}{{
+		name: "identical inputs",
+		verify: func(g *o.WithT, br *buildv1beta1.BuildRun) {
+			g.Expect(br.GenerateName).To(o.Equal("app-"))
+			g.Expect(br.Spec.BuildName()).To(o.Equal("app"))
+			g.Expect(br.Spec.ServiceAccount).To(o.Equal(ptr.To("builder")))
+			g.Expect(br.Spec.ParamValues).To(o.Equal(original.Spec.ParamValues))
+			g.Expect(br.Spec.Env).To(o.Equal(original.Spec.Env))
+			g.Expect(br.Spec.NodeSelector).To(o.Equal(original.Spec.NodeSelector))
+			g.Expect(br.Spec.Tolerations).To(o.Equal(original.Spec.Tolerations))
+			g.Expect(br.Spec.Output).To(o.Equal(original.Spec.Output))
+			g.Expect(br.Spec.State).To(o.BeNil())
+			g.Expect(br.Labels).To(o.BeEmpty())
+		},
+	}, {
+		name: "flags override the original values",
+		args: map[string]string{
+			flags.EnvFlag:          "DEBUG=true",
+			flags.NodeSelectorFlag: "disk=ssd",
+			flags.TolerationFlag:   "dedicated=builds:NoSchedule",
+			flags.VolumeFlag:       "cache=configmap:build-cache",
+		},
+		verify: func(g *o.WithT, br *buildv1beta1.BuildRun) {
+			g.Expect(br.Spec.Env).To(o.Equal([]corev1.EnvVar{
+				{Name: "DEBUG", Value: "true"},
+				{Name: "CGO_ENABLED", Value: "0"},
+			}))
+			g.Expect(br.Spec.NodeSelector).To(o.Equal(map[string]string{"kubernetes.io/arch": "amd64", "disk": "ssd"}))
+			g.Expect(br.Spec.Volumes).To(o.HaveLen(1))
+			g.Expect(br.Spec.Volumes[0].ConfigMap.Name).To(o.Equal("build-cache"))
+			g.Expect(br.Spec.Tolerations).To(o.Equal([]corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "builds",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}}))
+			g.Expect(br.Spec.ServiceAccount).To(o.Equal(ptr.To("builder")))
+		},
+	}, {
+		name: "pinned revision",
+		args: map[string]string{"pin-revision": "true"},
+		verify: func(g *o.WithT, br *buildv1beta1.BuildRun) {
+			g.Expect(br.Spec.Build.Name).To(o.BeNil())
+			g.Expect(br.Spec.Build.Spec).NotTo(o.BeNil())
+			g.Expect(br.Spec.Build.Spec.Source.Git.Revision).To(o.Equal(ptr.To("0123abc")))
+			g.Expect(br.Spec.Build.Spec.Source.Git.URL).To(o.Equal("https://github.com/shipwright-io/sample-go"))
+			g.Expect(br.Labels).To(o.HaveKeyWithValue(buildv1beta1.LabelBuild, "app"))
+			// overrides aren't allowed together with an embedded spec, they are folded into it
+			g.Expect(br.Spec.Output).To(o.BeNil())
+			g.Expect(br.Spec.ParamValues).To(o.BeNil())
+			g.Expect(br.Spec.Env).To(o.BeNil())
+			g.Expect(br.Spec.Build.Spec.Env).To(o.Equal(original.Spec.Env))
+			g.Expect(br.Spec.Build.Spec.ParamValues).To(o.Equal(original.Spec.ParamValues))
+			g.Expect(br.Spec.Build.Spec.Output).To(o.Equal(buildv1beta1.Image{
+				Image:       "registry/app:latest",
+				Labels:      map[string]string{"team": "blue"},
+				Annotations: map[string]string{"owner": "shipwright"},
+				Insecure:    ptr.To(false),
+			}))
+			// the original BuildRun status must not be changed
+			g.Expect(original.Status.BuildSpec.Source.Git.Revision).To(o.Equal(ptr.To("main")))
+			g.Expect(original.Status.BuildSpec.Output.Labels).To(o.BeNil())
+		},
+	}, {
+		name: "pinned revision with flag overrides",
+		args: map[string]string{
+			"pin-revision":        "true",
+			flags.EnvFlag:         "DEBUG=true",
+			flags.OutputImageFlag: "registry/app:debug",
+			flags.TimeoutFlag:     "10m",
+		},
+		verify: func(g *o.WithT, br *buildv1beta1.BuildRun) {
+			g.Expect(br.Spec.Output).To(o.BeNil())
+			g.Expect(br.Spec.Env).To(o.BeNil())
+			g.Expect(br.Spec.Timeout).To(o.BeNil())
+			g.Expect(br.Spec.Build.Spec.Env).To(o.Equal([]corev1.EnvVar{
+				{Name: "DEBUG", Value: "true"},
+				{Name: "CGO_ENABLED", Value: "0"},
+			}))
+			g.Expect(br.Spec.Build.Spec.Output.Image).To(o.Equal("registry/app:debug"))
+			g.Expect(br.Spec.Build.Spec.Output.Labels).To(o.Equal(map[string]string{"team": "blue"}))
+			g.Expect(br.Spec.Build.Spec.Timeout).To(o.Equal(&metav1.Duration{Duration: 10 * time.Minute}))
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			shpclient := shpfake.NewSimpleClientset(original)
+			var created *buildv1beta1.BuildRun
+			// the fake clientset doesn't honor generate-name, names are generated here instead
+			shpclient.PrependReactor("create", "buildruns", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				created = action.(k8stesting.CreateAction).GetObject().(*buildv1beta1.BuildRun)
+				created.Name = created.GenerateName + "xyz34"
+				return false, nil, nil
+			})
+			p := params.NewParamsForTest(nil, shpclient, nil, nil, "default", nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := rerunCmd().(*RerunCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(p, &ioStreams, []string{original.Name})).To(o.Succeed())
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			g.Expect(cmd.Run(p, &ioStreams)).To(o.Succeed())
+
+			g.Expect(out.String()).To(o.ContainSubstring(`BuildRun created "app-xyz34" as a rerun of "app-abc12"`))
+			g.Expect(created).NotTo(o.BeNil())
+			tt.verify(g, created)
+		})
+	}
+}
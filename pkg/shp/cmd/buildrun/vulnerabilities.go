@@ -0,0 +1,405 @@
+package buildrun
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// VulnerabilitiesCommand contains data input from user for vulnerabilities sub-command
+type VulnerabilitiesCommand struct {
+	cmd *cobra.Command
+
+	name       string
+	severities []string // severities to show, all when empty
+	failOn     string   // lowest severity which makes the command fail
+	output     string   // output format, table, json or sarif
+}
+
+const buildRunVulnerabilitiesLongDesc = `
+Shows the vulnerabilities found by the scan of the image built by the BuildRun, when the Build
+output has the vulnerability scan enabled. The findings can be filtered by severity:
+
+	$ shp buildrun vulnerabilities my-app-xyz12 --severity=critical,high
+
+The findings are also rendered as JSON, or as SARIF for code-scanning uploads:
+
+	$ shp buildrun vulnerabilities my-app-xyz12 --output=sarif > results.sarif
+
+For CI gating, the command exits with non-zero status when findings of the informed severity, or
+higher, are found:
+
+	$ shp buildrun vulnerabilities my-app-xyz12 --fail-on=high
+`
+
+// vulnerabilities output formats.
+const (
+	vulnerabilitiesTable = "table"
+	vulnerabilitiesJSON  = "json"
+	vulnerabilitiesSARIF = "sarif"
+)
+
+// severities known severities, from the highest to the lowest.
+var severities = []buildv1beta1.VulnerabilitySeverity{
+	buildv1beta1.Critical,
+	buildv1beta1.High,
+	buildv1beta1.Medium,
+	buildv1beta1.Low,
+	buildv1beta1.Unknown,
+}
+
+// severityRank returns the position of the severity, zero being the highest, unknown severities
+// are ranked the lowest.
+func severityRank(severity buildv1beta1.VulnerabilitySeverity) int {
+	for i, s := range severities {
+		if strings.EqualFold(string(s), string(severity)) {
+			return i
+		}
+	}
+	return len(severities) - 1
+}
+
+// isSeverity returns true when the informed value is a known severity.
+func isSeverity(value string) bool {
+	for _, s := range severities {
+		if string(s) == strings.ToLower(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func vulnerabilitiesCmd() runner.SubCommand {
+	cmd := &cobra.Command{
+		Use:     "vulnerabilities <name>",
+		Aliases: []string{"vulns"},
+		Short:   "Show the vulnerabilities found in the BuildRun image",
+		Long:    buildRunVulnerabilitiesLongDesc,
+		Args:    cobra.ExactArgs(1),
+	}
+	c := &VulnerabilitiesCommand{cmd: cmd}
+	cmd.Flags().StringSliceVar(&c.severities, "severity", nil, "Only show the findings of the informed severities (critical, high, medium, low, unknown)")
+	cmd.Flags().StringVar(&c.failOn, "fail-on", "", "Exit with non-zero status when findings of the informed severity, or higher, are found")
+	cmd.Flags().StringVarP(&c.output, "output", "o", vulnerabilitiesTable, "Output format. One of: (table, json, sarif).")
+	return c
+}
+
+// Cmd returns cobra command object
+func (c *VulnerabilitiesCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *VulnerabilitiesCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *VulnerabilitiesCommand) Validate() error {
+	for _, severity := range c.severities {
+		if !isSeverity(severity) {
+			return fmt.Errorf("unknown severity %q, one of: critical, high, medium, low, unknown", severity)
+		}
+	}
+	if c.failOn != "" && !isSeverity(c.failOn) {
+		return fmt.Errorf("unknown fail-on severity %q, one of: critical, high, medium, low, unknown", c.failOn)
+	}
+	switch c.output {
+	case vulnerabilitiesTable, vulnerabilitiesJSON, vulnerabilitiesSARIF:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, one of: table, json, sarif", c.output)
+	}
+}
+
+// Run executes vulnerabilities sub-command logic
+func (c *VulnerabilitiesCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	br, err := clientset.ShipwrightV1beta1().BuildRuns(params.Namespace()).Get(c.cmd.Context(), c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err := checkVulnerabilityScan(br); err != nil {
+		return err
+	}
+
+	findings := c.filter(br)
+	switch c.output {
+	case vulnerabilitiesJSON:
+		err = writeVulnerabilitiesJSON(ioStreams.Out, br, findings)
+	case vulnerabilitiesSARIF:
+		err = writeVulnerabilitiesSARIF(ioStreams.Out, br, findings)
+	default:
+		err = writeVulnerabilitiesTable(ioStreams.Out, br, findings)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.failOn == "" || br.Status.Output == nil {
+		return nil
+	}
+	// the gate considers every finding, regardless of the severities shown
+	threshold := severityRank(buildv1beta1.VulnerabilitySeverity(c.failOn))
+	failed := 0
+	for _, v := range br.Status.Output.Vulnerabilities {
+		if severityRank(v.Severity) <= threshold {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("BuildRun %q image has %d vulnerabilities of severity %q or higher", c.name, failed, c.failOn)
+	}
+	return nil
+}
+
+// checkVulnerabilityScan returns an error when the BuildRun has no scan results to show, either
+// because it is not done yet, or because its output has the vulnerability scan disabled.
+func checkVulnerabilityScan(br *buildv1beta1.BuildRun) error {
+	if !br.IsDone() {
+		return fmt.Errorf("BuildRun %q is not done yet, the vulnerability scan results are not available", br.Name)
+	}
+	output := resolvedOutput(br)
+	if output == nil || output.VulnerabilityScan == nil || !output.VulnerabilityScan.Enabled {
+		return fmt.Errorf("BuildRun %q output does not have the vulnerability scan enabled", br.Name)
+	}
+	return nil
+}
+
+// resolvedOutput returns the output of the BuildRun, which overrides the output of the Build.
+func resolvedOutput(br *buildv1beta1.BuildRun) *buildv1beta1.Image {
+	switch {
+	case br.Spec.Output != nil:
+		return br.Spec.Output
+	case br.Status.BuildSpec != nil:
+		return &br.Status.BuildSpec.Output
+	default:
+		return nil
+	}
+}
+
+// filter returns the vulnerabilities of the informed severities, ordered by severity and ID.
+func (c *VulnerabilitiesCommand) filter(br *buildv1beta1.BuildRun) []buildv1beta1.Vulnerability {
+	findings := []buildv1beta1.Vulnerability{}
+	if br.Status.Output == nil {
+		return findings
+	}
+	selected := map[int]bool{}
+	for _, severity := range c.severities {
+		selected[severityRank(buildv1beta1.VulnerabilitySeverity(severity))] = true
+	}
+	for _, v := range br.Status.Output.Vulnerabilities {
+		if len(selected) == 0 || selected[severityRank(v.Severity)] {
+			findings = append(findings, v)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		ri, rj := severityRank(findings[i].Severity), severityRank(findings[j].Severity)
+		if ri != rj {
+			return ri < rj
+		}
+		return findings[i].ID < findings[j].ID
+	})
+	return findings
+}
+
+// severityCounts returns the amount of findings per severity.
+func severityCounts(findings []buildv1beta1.Vulnerability) map[buildv1beta1.VulnerabilitySeverity]int {
+	counts := map[buildv1beta1.VulnerabilitySeverity]int{}
+	for _, v := range findings {
+		counts[severities[severityRank(v.Severity)]]++
+	}
+	return counts
+}
+
+// outputImage returns the image built by the BuildRun, when known.
+func outputImage(br *buildv1beta1.BuildRun) string {
+	switch {
+	case br.Spec.Output != nil && br.Spec.Output.Image != "":
+		return br.Spec.Output.Image
+	case br.Status.BuildSpec != nil:
+		return br.Status.BuildSpec.Output.Image
+	default:
+		return ""
+	}
+}
+
+// writeVulnerabilitiesTable writes the findings as a table, followed by the counts per severity.
+func writeVulnerabilitiesTable(w io.Writer, br *buildv1beta1.BuildRun, findings []buildv1beta1.Vulnerability) error {
+	if len(findings) == 0 {
+		fmt.Fprintf(w, "No vulnerabilities found for BuildRun %q\n", br.Name)
+		return nil
+	}
+	writer := tabwriter.NewWriter(w, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(writer, "ID\tSEVERITY")
+	for _, v := range findings {
+		fmt.Fprintf(writer, "%s\t%s\n", v.ID, strings.ToUpper(string(v.Severity)))
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	counts := severityCounts(findings)
+	parts := []string{}
+	for _, s := range severities {
+		parts = append(parts, fmt.Sprintf("%s: %d", strings.ToUpper(string(s)), counts[s]))
+	}
+	fmt.Fprintf(w, "\nTotal: %d (%s)\n", len(findings), strings.Join(parts, ", "))
+	return nil
+}
+
+// vulnerabilitiesReport is the JSON representation of the findings.
+type vulnerabilitiesReport struct {
+	BuildRun        string                                     `json:"buildRun"`
+	Image           string                                     `json:"image,omitempty"`
+	Digest          string                                     `json:"digest,omitempty"`
+	Total           int                                        `json:"total"`
+	Counts          map[buildv1beta1.VulnerabilitySeverity]int `json:"counts"`
+	Vulnerabilities []buildv1beta1.Vulnerability               `json:"vulnerabilities"`
+}
+
+// writeVulnerabilitiesJSON writes the findings, with the counts per severity, as JSON.
+func writeVulnerabilitiesJSON(w io.Writer, br *buildv1beta1.BuildRun, findings []buildv1beta1.Vulnerability) error {
+	report := vulnerabilitiesReport{
+		BuildRun:        br.Name,
+		Image:           outputImage(br),
+		Total:           len(findings),
+		Counts:          severityCounts(findings),
+		Vulnerabilities: findings,
+	}
+	if br.Status.Output != nil {
+		report.Digest = br.Status.Output.Digest
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// SARIF 2.1.0 log, only the attributes required by code-scanning tools are represented.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string            `json:"id"`
+	ShortDescription sarifMessage      `json:"shortDescription"`
+	Properties       map[string]string `json:"properties"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifLevel returns the SARIF level and the numeric security severity of a vulnerability severity.
+func sarifLevel(severity buildv1beta1.VulnerabilitySeverity) (string, string) {
+	switch severities[severityRank(severity)] {
+	case buildv1beta1.Critical:
+		return "error", "9.0"
+	case buildv1beta1.High:
+		return "error", "7.0"
+	case buildv1beta1.Medium:
+		return "warning", "5.0"
+	case buildv1beta1.Low:
+		return "note", "3.0"
+	default:
+		return "none", "0.0"
+	}
+}
+
+// writeVulnerabilitiesSARIF writes the findings as a SARIF log, the image is the location of every
+// result.
+func writeVulnerabilitiesSARIF(w io.Writer, br *buildv1beta1.BuildRun, findings []buildv1beta1.Vulnerability) error {
+	image := outputImage(br)
+	if image == "" {
+		image = br.Name
+	}
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "shp",
+			InformationURI: "https://github.com/shipwright-io/cli",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	rules := map[string]bool{}
+	for _, v := range findings {
+		level, securitySeverity := sarifLevel(v.Severity)
+		if !rules[v.ID] {
+			rules[v.ID] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               v.ID,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("%s severity vulnerability %s", v.Severity, v.ID)},
+				Properties:       map[string]string{"security-severity": securitySeverity},
+			})
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  v.ID,
+			Level:   level,
+			Message: sarifMessage{Text: fmt.Sprintf("Image %s is affected by %s (%s)", image, v.ID, v.Severity)},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: image}},
+			}},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,172 @@
+package buildrun
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestBuildRunVulnerabilities(t *testing.T) {
+	br := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "app-xyz12"},
+		Spec: buildv1beta1.BuildRunSpec{
+			Output: &buildv1beta1.Image{
+				Image:             "registry/app:latest",
+				VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{Enabled: true},
+			},
+		},
+		Status: buildv1beta1.BuildRunStatus{
+			Conditions: buildv1beta1.Conditions{{
+				Type:   buildv1beta1.Succeeded,
+				Status: corev1.ConditionTrue,
+			}},
+			Output: &buildv1beta1.Output{
+				Digest: "sha256:0123",
+				Vulnerabilities: []buildv1beta1.Vulnerability{
+					{ID: "CVE-2024-0003", Severity: buildv1beta1.Low},
+					{ID: "CVE-2024-0002", Severity: buildv1beta1.Critical},
+					{ID: "CVE-2024-0001", Severity: buildv1beta1.High},
+					{ID: "CVE-2024-0004", Severity: buildv1beta1.High},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]string
+		modify      func(br *buildv1beta1.BuildRun)
+		validateErr bool
+		err         string
+		verify      func(g *o.WithT, out string)
+	}{{
+		name: "table with counts",
+		verify: func(g *o.WithT, out string) {
+			g.Expect(out).To(o.MatchRegexp(`(?s)CVE-2024-0002\s+CRITICAL.*CVE-2024-0001\s+HIGH.*CVE-2024-0004\s+HIGH.*CVE-2024-0003\s+LOW`))
+			g.Expect(out).To(o.ContainSubstring("Total: 4 (CRITICAL: 1, HIGH: 2, MEDIUM: 0, LOW: 1, UNKNOWN: 0)"))
+		},
+	}, {
+		name: "severity filter",
+		args: map[string]string{"severity": "critical,low"},
+		verify: func(g *o.WithT, out string) {
+			g.Expect(out).NotTo(o.ContainSubstring("HIGH\n"))
+			g.Expect(out).To(o.ContainSubstring("Total: 2 "))
+		},
+	}, {
+		name: "json",
+		args: map[string]string{"output": "json", "severity": "high"},
+		verify: func(g *o.WithT, out string) {
+			report := vulnerabilitiesReport{}
+			g.Expect(json.Unmarshal([]byte(out), &report)).To(o.Succeed())
+			g.Expect(report.Image).To(o.Equal("registry/app:latest"))
+			g.Expect(report.Digest).To(o.Equal("sha256:0123"))
+			g.Expect(report.Total).To(o.Equal(2))
+			g.Expect(report.Counts).To(o.Equal(map[buildv1beta1.VulnerabilitySeverity]int{buildv1beta1.High: 2}))
+		},
+	}, {
+		name: "sarif",
+		args: map[string]string{"output": "sarif"},
+		verify: func(g *o.WithT, out string) {
+			log := sarifLog{}
+			g.Expect(json.Unmarshal([]byte(out), &log)).To(o.Succeed())
+			g.Expect(log.Version).To(o.Equal("2.1.0"))
+			g.Expect(log.Runs).To(o.HaveLen(1))
+			g.Expect(log.Runs[0].Tool.Driver.Rules).To(o.HaveLen(4))
+			g.Expect(log.Runs[0].Results[0].RuleID).To(o.Equal("CVE-2024-0002"))
+			g.Expect(log.Runs[0].Results[0].Level).To(o.Equal("error"))
+			g.Expect(log.Runs[0].Results[3].Level).To(o.Equal("note"))
+			g.Expect(log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI).
+				To(o.Equal("registry/app:latest"))
+		},
+	}, {
+		name: "below the fail-on threshold",
+		args: map[string]string{"fail-on": "critical"},
+		modify: func(br *buildv1beta1.BuildRun) {
+			br.Status.Output.Vulnerabilities = br.Status.Output.Vulnerabilities[:1]
+		},
+	}, {
+		name: "fail-on ignores the severity filter",
+		args: map[string]string{"fail-on": "critical", "severity": "high,low"},
+		err:  `BuildRun "app-xyz12" image has 1 vulnerabilities of severity "critical" or higher`,
+	}, {
+		name: "not done",
+		modify: func(br *buildv1beta1.BuildRun) {
+			br.Status.Conditions[0].Status = corev1.ConditionUnknown
+		},
+		err: `BuildRun "app-xyz12" is not done yet, the vulnerability scan results are not available`,
+	}, {
+		name: "scan disabled",
+		modify: func(br *buildv1beta1.BuildRun) {
+			br.Spec.Output = nil
+			br.Status.BuildSpec = &buildv1beta1.BuildSpec{Output: buildv1beta1.Image{Image: "registry/app:latest"}}
+		},
+		err: `BuildRun "app-xyz12" output does not have the vulnerability scan enabled`,
+	}, {
+		name: "scan enabled on the Build",
+		modify: func(br *buildv1beta1.BuildRun) {
+			br.Spec.Output = nil
+			br.Status.BuildSpec = &buildv1beta1.BuildSpec{Output: buildv1beta1.Image{
+				Image:             "registry/app:latest",
+				VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{Enabled: true},
+			}}
+		},
+		verify: func(g *o.WithT, out string) {
+			g.Expect(out).To(o.ContainSubstring("Total: 4 "))
+		},
+	}, {
+		name: "above the fail-on threshold",
+		args: map[string]string{"fail-on": "high"},
+		err:  `BuildRun "app-xyz12" image has 3 vulnerabilities of severity "high" or higher`,
+	}, {
+		name:        "unknown severity",
+		args:        map[string]string{"severity": "severe"},
+		validateErr: true,
+	}, {
+		name:        "unknown output format",
+		args:        map[string]string{"output": "yaml"},
+		validateErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			br := br.DeepCopy()
+			if tt.modify != nil {
+				tt.modify(br)
+			}
+			p := params.NewParamsForTest(nil, shpfake.NewSimpleClientset(br), nil, nil, metav1.NamespaceDefault, nil, nil)
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := vulnerabilitiesCmd().(*VulnerabilitiesCommand)
+			cmd.Cmd().SetContext(context.Background())
+			for k, v := range tt.args {
+				g.Expect(cmd.Cmd().Flags().Set(k, v)).To(o.Succeed())
+			}
+
+			g.Expect(cmd.Complete(p, &ioStreams, []string{br.Name})).To(o.Succeed())
+			if tt.validateErr {
+				g.Expect(cmd.Validate()).NotTo(o.Succeed())
+				return
+			}
+			g.Expect(cmd.Validate()).To(o.Succeed())
+			err := cmd.Run(p, &ioStreams)
+			if tt.err != "" {
+				g.Expect(err).To(o.MatchError(tt.err))
+			} else {
+				g.Expect(err).NotTo(o.HaveOccurred())
+			}
+			if tt.verify != nil {
+				tt.verify(g, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package buildrun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/cmd/waiter"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// WaitCommand contains data input from user for wait sub-command
+type WaitCommand struct {
+	cmd *cobra.Command
+
+	name    string
+	timeout time.Duration
+}
+
+const buildRunWaitLongDesc = `
+Waits until the BuildRun is completed, without streaming its logs. The command exits with non-zero
+status when the BuildRun fails, is canceled, or doesn't complete within the informed timeout.
+Example:
+
+	$ shp buildrun wait my-app-xyz12 --timeout=30m
+`
+
+func waitCmd() runner.SubCommand {
+	waitCommand := &WaitCommand{
+		cmd: &cobra.Command{
+			Use:   "wait <name>",
+			Short: "Wait for a BuildRun to complete",
+			Long:  buildRunWaitLongDesc,
+			Args:  cobra.ExactArgs(1),
+		},
+	}
+	waitCommand.cmd.Flags().DurationVar(&waitCommand.timeout, "timeout", 0, "Maximum time to wait for the BuildRun to complete, zero means no limit")
+	return waitCommand
+}
+
+// Cmd returns cobra command object
+func (c *WaitCommand) Cmd() *cobra.Command {
+	return c.cmd
+}
+
+// Complete fills in data provided by user
+func (c *WaitCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *WaitCommand) Validate() error {
+	if c.timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	return nil
+}
+
+// Run executes wait sub-command logic
+func (c *WaitCommand) Run(params *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	clientset, err := params.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+
+	if _, err = waiter.Wait(c.cmd.Context(), clientset, params.Namespace(), c.name, c.timeout); err != nil {
+		return err
+	}
+	fmt.Fprintf(ioStreams.Out, "BuildRun %q has succeeded\n", c.name)
+	return nil
+}
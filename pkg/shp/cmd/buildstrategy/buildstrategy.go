@@ -21,6 +21,10 @@ func Command(p *params.Params, ioStreams *genericclioptions.IOStreams) *cobra.Co
 
 	cmd.AddCommand(
 		runner.NewRunner(p, ioStreams, listCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, describeCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, createCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, applyCmd()).Cmd(),
+		runner.NewRunner(p, ioStreams, exportCmd()).Cmd(),
 		runner.NewRunner(p, ioStreams, deleteCmd()).Cmd(),
 	)
 
@@ -0,0 +1,84 @@
+package buildstrategy
+
+import (
+	"context"
+	"fmt"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/manifest"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// CreateCommand contains data input from user for create and apply sub-commands
+type CreateCommand struct {
+	*manifest.StrategyCommand
+	strategies []*buildv1beta1.BuildStrategy
+}
+
+// strategyKind describes the BuildStrategy kind for the create and apply sub-commands.
+var strategyKind = manifest.StrategyKind{
+	Kind:    "BuildStrategy",
+	Plural:  "BuildStrategies",
+	Command: "buildstrategy",
+	Scope:   " in the current namespace",
+}
+
+func createCmd() runner.SubCommand {
+	return &CreateCommand{StrategyCommand: manifest.NewStrategyCommand(strategyKind, false)}
+}
+
+func applyCmd() runner.SubCommand {
+	return &CreateCommand{StrategyCommand: manifest.NewStrategyCommand(strategyKind, true)}
+}
+
+// Complete reads and validates the BuildStrategies on the informed manifest
+func (c *CreateCommand) Complete(p *params.Params, ioStreams *genericclioptions.IOStreams, _ []string) error {
+	objects, err := c.Read(ioStreams.In)
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		bs, ok := o.Object.(*buildv1beta1.BuildStrategy)
+		if !ok {
+			return fmt.Errorf("%s: expected BuildStrategy, found %s %q", o.Source, o.Kind(), o.Name())
+		}
+		if bs.Namespace == "" {
+			bs.Namespace = p.Namespace()
+		}
+		c.strategies = append(c.strategies, bs)
+	}
+	return nil
+}
+
+// Validate validates data input by user
+func (c *CreateCommand) Validate() error { return nil }
+
+// Run executes create or apply sub-command logic
+func (c *CreateCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	cs, err := p.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	for _, bs := range c.strategies {
+		client := cs.ShipwrightV1beta1().BuildStrategies(bs.Namespace)
+		err := c.Submit(c.Cmd().Context(), ioStreams.Out, bs, bs.Name,
+			func(ctx context.Context) error {
+				_, err := client.Create(ctx, bs, metav1.CreateOptions{})
+				return err
+			},
+			func(ctx context.Context, data []byte, opts metav1.PatchOptions) error {
+				_, err := client.Patch(ctx, bs.Name, types.ApplyPatchType, data, opts)
+				return err
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package buildstrategy
+
+import (
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/describe"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// DescribeCommand contains data input from user for describe sub-command
+type DescribeCommand struct {
+	cmd  *cobra.Command
+	name string
+}
+
+func describeCmd() runner.SubCommand {
+	return &DescribeCommand{
+		cmd: &cobra.Command{
+			Use:   "describe <name>",
+			Short: "Show details of a BuildStrategy in the current namespace",
+			Long: `
+Shows the details of a BuildStrategy, including its steps, resources, parameters, overridable
+volumes and related events. For example:
+
+	$ shp buildstrategy describe buildah
+`,
+			Args: cobra.ExactArgs(1),
+		},
+	}
+}
+
+// Cmd returns cobra command object
+func (c *DescribeCommand) Cmd() *cobra.Command { return c.cmd }
+
+// Complete fills in data provided by user
+func (c *DescribeCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *DescribeCommand) Validate() error { return nil }
+
+// Run executes describe sub-command logic
+func (c *DescribeCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	ctx := c.cmd.Context()
+	cs, err := p.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	k8sclient, err := p.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	bs, err := cs.ShipwrightV1beta1().BuildStrategies(p.Namespace()).Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	events, err := describe.Events(ctx, k8sclient, p.Namespace(), describe.InvolvedObject{
+		Kind: "BuildStrategy",
+		Name: bs.GetName(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return describe.Tabbed(ioStreams.Out, func(w kdescribe.PrefixWriter) error {
+		describe.ObjectMeta(w, bs.ObjectMeta)
+		describe.BuildStrategySpec(w, &bs.Spec)
+		kdescribe.DescribeEvents(events, w)
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package buildstrategy
+
+import (
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/manifest"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// ExportCommand contains data input from user for export sub-command
+type ExportCommand struct {
+	cmd  *cobra.Command
+	name string
+}
+
+func exportCmd() runner.SubCommand {
+	return &ExportCommand{
+		cmd: &cobra.Command{
+			Use:   "export <name>",
+			Short: "Export a BuildStrategy in the current namespace as a portable manifest",
+			Long: `
+Exports a BuildStrategy as a portable YAML manifest, without status, namespace and attributes
+managed by the cluster. For example:
+
+	$ shp buildstrategy export buildah > buildah.yaml
+`,
+			Args: cobra.ExactArgs(1),
+		},
+	}
+}
+
+// Cmd returns cobra command object
+func (c *ExportCommand) Cmd() *cobra.Command { return c.cmd }
+
+// Complete fills in data provided by user
+func (c *ExportCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *ExportCommand) Validate() error { return nil }
+
+// Run executes export sub-command logic
+func (c *ExportCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	cs, err := p.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	bs, err := cs.ShipwrightV1beta1().BuildStrategies(p.Namespace()).Get(c.cmd.Context(), c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return manifest.WriteYAML(ioStreams.Out, nil, bs)
+}
@@ -13,12 +13,14 @@ import (
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
 )
 
 // ListCommand contains data input from user for list sub-command
 type ListCommand struct {
-	cmd      *cobra.Command
-	noHeader bool
+	cmd        *cobra.Command
+	noHeader   bool
+	printFlags *printer.PrintFlags
 }
 
 func listCmd() runner.SubCommand {
@@ -27,8 +29,10 @@ func listCmd() runner.SubCommand {
 			Use:   "list [flags]",
 			Short: "List BuildStrategies in the current namespace",
 		},
+		printFlags: printer.NewPrintFlags(),
 	}
 	c.cmd.Flags().BoolVar(&c.noHeader, "no-header", false, "Do not print the table header")
+	c.printFlags.AddFlags(c.cmd)
 	return c
 }
 
@@ -44,16 +48,15 @@ func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams,
 
 // Validate validates data input by user
 func (c *ListCommand) Validate() error {
-	return nil
+	// the table has no additional columns to show
+	if c.printFlags.IsWide() {
+		return fmt.Errorf("output format %q is not supported", c.printFlags.OutputFormat)
+	}
+	return c.printFlags.Validate()
 }
 
 // Run executes list sub-command logic
 func (c *ListCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
-	w := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
-	if !c.noHeader {
-		fmt.Fprintln(w, "NAME\tAGE")
-	}
-
 	cs, err := p.ShipwrightClientSet()
 	if err != nil {
 		return err
@@ -76,11 +79,19 @@ func (c *ListCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStrea
 	if err != nil {
 		return err
 	}
+	if !c.printFlags.IsHumanReadable() {
+		return c.printFlags.Print(list, c.noHeader, ioStreams.Out)
+	}
 	if len(list.Items) == 0 {
 		fmt.Fprintf(ioStreams.Out, "No BuildStrategies found in namespace '%s'.\n", ns)
 		return nil
 	}
 
+	w := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
+	if !c.noHeader {
+		fmt.Fprintln(w, "NAME\tAGE")
+	}
+
 	now := time.Now()
 	for _, bs := range list.Items {
 		age := duration.ShortHumanDuration(now.Sub(bs.CreationTimestamp.Time))
@@ -0,0 +1,79 @@
+package clusterbuildstrategy
+
+import (
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+
+	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
+	"github.com/shipwright-io/cli/pkg/shp/describe"
+	"github.com/shipwright-io/cli/pkg/shp/params"
+)
+
+// DescribeCommand contains data input from user for describe sub-command
+type DescribeCommand struct {
+	cmd  *cobra.Command
+	name string
+}
+
+func describeCmd() runner.SubCommand {
+	return &DescribeCommand{
+		cmd: &cobra.Command{
+			Use:   "describe <name>",
+			Short: "Show details of a ClusterBuildStrategy",
+			Long: `
+Shows the details of a ClusterBuildStrategy, including its steps, resources, parameters,
+overridable volumes and related events. For example:
+
+	$ shp clusterbuildstrategy describe buildah
+`,
+			Args: cobra.ExactArgs(1),
+		},
+	}
+}
+
+// Cmd returns cobra command object
+func (c *DescribeCommand) Cmd() *cobra.Command { return c.cmd }
+
+// Complete fills in data provided by user
+func (c *DescribeCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams, args []string) error {
+	c.name = args[0]
+	return nil
+}
+
+// Validate validates data input by user
+func (c *DescribeCommand) Validate() error { return nil }
+
+// Run executes describe sub-command logic
+func (c *DescribeCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
+	ctx := c.cmd.Context()
+	cs, err := p.ShipwrightClientSet()
+	if err != nil {
+		return err
+	}
+	k8sclient, err := p.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	cbs, err := cs.ShipwrightV1beta1().ClusterBuildStrategies().Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// cluster scoped objects have their events recorded on the default namespace
+	events, err := describe.Events(ctx, k8sclient, metav1.NamespaceDefault, describe.InvolvedObject{
+		Kind: "ClusterBuildStrategy",
+		Name: cbs.GetName(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return describe.Tabbed(ioStreams.Out, func(w kdescribe.PrefixWriter) error {
+		describe.ObjectMeta(w, cbs.ObjectMeta)
+		describe.BuildStrategySpec(w, &cbs.Spec)
+		kdescribe.DescribeEvents(events, w)
+		return nil
+	})
+}
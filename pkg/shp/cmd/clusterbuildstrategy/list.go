@@ -12,12 +12,14 @@ import (
 
 	"github.com/shipwright-io/cli/pkg/shp/cmd/runner"
 	"github.com/shipwright-io/cli/pkg/shp/params"
+	"github.com/shipwright-io/cli/pkg/shp/printer"
 )
 
 // ListCommand contains data input from user for list sub-command
 type ListCommand struct {
-	cmd      *cobra.Command
-	noHeader bool
+	cmd        *cobra.Command
+	noHeader   bool
+	printFlags *printer.PrintFlags
 }
 
 func listCmd() runner.SubCommand {
@@ -26,8 +28,10 @@ func listCmd() runner.SubCommand {
 			Use:   "list [flags]",
 			Short: "List ClusterBuildStrategies",
 		},
+		printFlags: printer.NewPrintFlags(),
 	}
 	c.cmd.Flags().BoolVar(&c.noHeader, "no-header", false, "Omit table header")
+	c.printFlags.AddFlags(c.cmd)
 	return c
 }
 
@@ -43,16 +47,15 @@ func (c *ListCommand) Complete(_ *params.Params, _ *genericclioptions.IOStreams,
 
 // Validate validates data input by user
 func (c *ListCommand) Validate() error {
-	return nil
+	// the table has no additional columns to show
+	if c.printFlags.IsWide() {
+		return fmt.Errorf("output format %q is not supported", c.printFlags.OutputFormat)
+	}
+	return c.printFlags.Validate()
 }
 
 // Run executes list sub-command logic
 func (c *ListCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStreams) error {
-	w := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
-	if !c.noHeader {
-		fmt.Fprintln(w, "NAME\tAGE")
-	}
-
 	cs, err := p.ShipwrightClientSet()
 	if err != nil {
 		return err
@@ -61,11 +64,19 @@ func (c *ListCommand) Run(p *params.Params, ioStreams *genericclioptions.IOStrea
 	if err != nil {
 		return err
 	}
+	if !c.printFlags.IsHumanReadable() {
+		return c.printFlags.Print(list, c.noHeader, ioStreams.Out)
+	}
 	if len(list.Items) == 0 {
 		fmt.Fprintln(ioStreams.Out, "No ClusterBuildStrategies found.")
 		return nil
 	}
 
+	w := tabwriter.NewWriter(ioStreams.Out, 0, 8, 2, '\t', 0)
+	if !c.noHeader {
+		fmt.Fprintln(w, "NAME\tAGE")
+	}
+
 	now := time.Now()
 	for _, cbs := range list.Items {
 		age := duration.ShortHumanDuration(now.Sub(cbs.CreationTimestamp.Time))
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"sync"
+	"text/tabwriter"
 	"time"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
@@ -14,6 +17,7 @@ import (
 	"github.com/shipwright-io/cli/pkg/shp/reactor"
 	"github.com/shipwright-io/cli/pkg/shp/tail"
 	shputil "github.com/shipwright-io/cli/pkg/shp/util"
+	"golang.org/x/term"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,8 +26,26 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 )
 
+// PipelineTaskLabel is the label carrying the pipeline task name on the pods of a PipelineRun.
+const PipelineTaskLabel = "tekton.dev/pipelineTask"
+
+// DefaultPendingGracePeriod is the default time a pod may be unschedulable or failing to pull images.
+const DefaultPendingGracePeriod = 5 * time.Minute
+
+// TaskRunLabel and PipelineRunLabel are the labels carrying the executor name on the BuildRun pods.
+const (
+	TaskRunLabel     = "tekton.dev/taskRun"
+	PipelineRunLabel = "tekton.dev/pipelineRun"
+)
+
+// PodTask returns the pipeline task executed by the pod, empty for pods of a TaskRun executor.
+func PodTask(pod *corev1.Pod) string {
+	return pod.GetLabels()[PipelineTaskLabel]
+}
+
 // Follower encapsulate the function of tailing the logs for Pods derived from BuildRuns
 type Follower struct {
 	ctx            context.Context              // global context instance
@@ -33,14 +55,22 @@ type Follower struct {
 	clientset      kubernetes.Interface         // kubernetes api-client
 	buildClientset buildclientset.Interface     // shipwright api-client
 
-	logTail         *tail.Tail      // follow container logs
-	tailLogsStarted map[string]bool // controls tail instance per container
+	logTail         *tail.Tail                              // follow container logs
+	tailLogsStarted map[string]bool                         // controls tail instance per pod container
+	containerFilter func(pod *corev1.Pod, name string) bool // selects the containers to follow, all when nil
+	logOptions      corev1.PodLogOptions                    // options for the container log requests
 
-	logLock             sync.Mutex // avoiding race condition to print logs
-	enteredRunningState bool       // target pod is running
+	logLock             sync.Mutex      // avoiding race condition to print logs
+	enteredRunningState map[string]bool // target pods that are running
 
 	failPollInterval time.Duration // for use in the PollInterval call when processing failed pods
 	failPollTimeout  time.Duration // for use in the PollInterval call when processing failed pods
+
+	pendingGracePeriod time.Duration          // time a pod may be unschedulable or failing to pull images
+	pendingSince       map[string]time.Time   // when each pod started being unschedulable or failing to pull images
+	pods               map[string]*corev1.Pod // last observed state of the BuildRun pods
+	involvedObjects    map[string]bool        // names of the objects whose events are shown
+	reportedEvents     map[types.UID]bool     // events already shown
 }
 
 // NewFollower returns a Follower instance.
@@ -60,14 +90,28 @@ func NewFollower(
 		clientset:      clientset,
 		buildClientset: buildClientset,
 
-		logTail:          tail.NewTail(ctx, clientset),
-		logLock:          sync.Mutex{},
-		tailLogsStarted:  map[string]bool{},
-		failPollInterval: 1 * time.Second,
-		failPollTimeout:  15 * time.Second,
+		logTail:             tail.NewTail(ctx, clientset),
+		logLock:             sync.Mutex{},
+		tailLogsStarted:     map[string]bool{},
+		enteredRunningState: map[string]bool{},
+		failPollInterval:    1 * time.Second,
+		failPollTimeout:     15 * time.Second,
+		pendingGracePeriod:  DefaultPendingGracePeriod,
+		pendingSince:        map[string]time.Time{},
+		pods:                map[string]*corev1.Pod{},
+		involvedObjects:     map[string]bool{},
+		reportedEvents:      map[types.UID]bool{},
 	}
 
+	// the container logs share the output streams with the follower messages
+	f.logTail.SetStdout(&lockedWriter{lock: &f.logLock, w: ioStreams.Out})
+	f.logTail.SetStderr(&lockedWriter{lock: &f.logLock, w: ioStreams.ErrOut})
+
+	f.pw.WithOnPodAddedFn(f.OnPodAdded)
 	f.pw.WithOnPodModifiedFn(f.OnEvent)
+	f.pw.WithOnEventFn(f.OnKubernetesEvent)
+	f.pw.WithEventsErrorFn(f.OnEventsError)
+	f.pw.WithOnRecheckFn(f.OnRecheck)
 	f.pw.WithTimeoutPodFn(f.OnTimeout)
 	f.pw.WithNoPodEventsYetFn(f.OnNoPodEventsYet)
 
@@ -91,6 +135,66 @@ func (f *Follower) SetFailPollTimeout(t time.Duration) {
 	f.failPollTimeout = t
 }
 
+// SetPendingGracePeriod sets for how long a pod may be unschedulable or failing to pull images
+// before the log following fails, zero disables the check.
+func (f *Follower) SetPendingGracePeriod(t time.Duration) {
+	f.pendingGracePeriod = t
+}
+
+// SetContainerFilter limits the containers whose logs are followed to the ones the informed function
+// returns true for, by default all containers are followed.
+func (f *Follower) SetContainerFilter(fn func(pod *corev1.Pod, name string) bool) {
+	f.containerFilter = fn
+}
+
+// SetLogOptions sets the options employed to request the container logs, like the amount of lines
+// or the timestamps.
+func (f *Follower) SetLogOptions(opts corev1.PodLogOptions) {
+	f.logOptions = opts
+	f.logTail.SetLogOptions(opts)
+}
+
+// SetOutputOptions controls the step name prefix on each log line, and whether the prefix is
+// colorized, colors are only employed when the output is a terminal.
+func (f *Follower) SetOutputOptions(prefix, color bool) {
+	f.logTail.SetPrefix(prefix)
+	f.logTail.SetColor(color && isTerminal(f.ioStreams.Out))
+}
+
+// isTerminal returns true when the informed writer is a terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// lockedWriter serializes the writes on the informed writer, sharing the lock with the follower.
+type lockedWriter struct {
+	lock *sync.Mutex
+	w    io.Writer
+}
+
+// Write writes on the underlying writer holding the lock.
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.w.Write(p)
+}
+
+// isFollowed returns true when the container logs should be shown.
+func (f *Follower) isFollowed(pod *corev1.Pod, name string) bool {
+	return f.containerFilter == nil || f.containerFilter(pod, name)
+}
+
+// StepName returns the name identifying the container logs, the step name with the "step-"
+// prefix stripped, preceded by the task name for pods of a PipelineRun.
+func StepName(pod *corev1.Pod, container string) string {
+	step := strings.TrimPrefix(container, "step-")
+	if task := PodTask(pod); task != "" {
+		return fmt.Sprintf("%s/%s", task, step)
+	}
+	return step
+}
+
 // GetLogLock returns the mutex used for coordinating access to log buffers.
 func (f *Follower) GetLogLock() *sync.Mutex {
 	return &f.logLock
@@ -105,14 +209,15 @@ func (f *Follower) Log(msg string) {
 }
 
 // tailLogs start tailing logs for each container name in init-containers and containers, if not
-// started already.
+// started already. The tail resumes by itself when the log stream is cut while the container runs.
 func (f *Follower) tailLogs(pod *corev1.Pod) {
 	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
-		if _, exists := f.tailLogsStarted[container.Name]; exists {
+		key := fmt.Sprintf("%s/%s", pod.GetName(), container.Name)
+		if _, exists := f.tailLogsStarted[key]; exists || !f.isFollowed(pod, container.Name) {
 			continue
 		}
-		f.tailLogsStarted[container.Name] = true
-		f.logTail.Start(pod.GetNamespace(), pod.GetName(), container.Name)
+		f.tailLogsStarted[key] = true
+		f.logTail.StartWithName(pod.GetNamespace(), pod.GetName(), container.Name, StepName(pod, container.Name))
 	}
 }
 
@@ -122,19 +227,26 @@ func (f *Follower) Stop() {
 	f.pw.Stop()
 }
 
+// OnPodAdded records the pod created for the BuildRun, so its Kubernetes events are shown.
+func (f *Follower) OnPodAdded(pod *corev1.Pod) error {
+	f.trackPod(pod)
+	return f.checkPending(pod)
+}
+
 // OnEvent reacts on pod state changes, to start and stop tailing container logs.
 func (f *Follower) OnEvent(pod *corev1.Pod) error {
+	f.trackPod(pod)
 	switch pod.Status.Phase {
 	case corev1.PodRunning:
-		if !f.enteredRunningState {
+		if !f.enteredRunningState[pod.GetName()] {
 			f.Log(fmt.Sprintf("Pod %q in %q state, starting up log tail\n", pod.GetName(), corev1.PodRunning))
 			for _, c := range pod.Status.ContainerStatuses {
 				if c.State.Running != nil && !c.State.Running.StartedAt.IsZero() {
-					f.enteredRunningState = true
+					f.enteredRunningState[pod.GetName()] = true
 					break
 				}
 			}
-			if f.enteredRunningState {
+			if f.enteredRunningState[pod.GetName()] {
 				f.tailLogs(pod)
 			}
 		}
@@ -169,7 +281,7 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 		case pod.DeletionTimestamp != nil:
 			msg = fmt.Sprintf("Pod %q has been deleted.\n", pod.GetName())
 		default:
-			msg = buildErrorMessage(br, pod)
+			msg = buildErrorMessage(br, pod) + f.failureSummary(br, pod)
 			err = fmt.Errorf("buildrun pod %q has failed", pod.GetName())
 		}
 		// see if because of deletion or cancelation
@@ -179,11 +291,14 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 	case corev1.PodSucceeded:
 		// encountered scenarios where the build run quickly enough that the pod effectively skips the running state,
 		// or the events come in reverse order, and we never enter the tail
-		if !f.enteredRunningState {
+		if !f.enteredRunningState[pod.GetName()] {
 			f.Log(fmt.Sprintf("succeeded event for pod %q arrived before or in place of running event so dumping logs now\n", pod.GetName()))
 			var b strings.Builder
 			for _, c := range pod.Spec.Containers {
-				logs, err := shputil.GetPodLogs(f.ctx, f.clientset, *pod, c.Name)
+				if !f.isFollowed(pod, c.Name) {
+					continue
+				}
+				logs, err := shputil.GetPodLogsWithOptions(f.ctx, f.clientset, *pod, c.Name, f.logOptions)
 				if err != nil {
 					f.Log(fmt.Sprintf("could not get logs for container %q: %s\n", c.Name, err.Error()))
 					continue
@@ -194,9 +309,16 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 			f.Log(b.String())
 		}
 		f.Log(fmt.Sprintf("Pod %q has succeeded!\n", pod.GetName()))
+		// a PipelineRun executes one pod per task, following continues until the BuildRun is done
+		if PodTask(pod) != "" && !f.pipelineRunDone(pod) {
+			return nil
+		}
 		f.Stop()
 	default:
 		f.Log(fmt.Sprintf("Pod %q is in state %q...\n", pod.GetName(), string(pod.Status.Phase)))
+		if err := f.checkPending(pod); err != nil {
+			return err
+		}
 		// handle any issues with pulling images that may fail
 		for _, c := range pod.Status.Conditions {
 			if c.Type == corev1.PodInitialized || c.Type == corev1.ContainersReady {
@@ -210,6 +332,129 @@ func (f *Follower) OnEvent(pod *corev1.Pod) error {
 
 }
 
+// trackPod records the pod, and the TaskRun or PipelineRun which created it, as objects whose
+// Kubernetes events are shown.
+func (f *Follower) trackPod(pod *corev1.Pod) {
+	f.pods[pod.GetName()] = pod
+	f.involvedObjects[pod.GetName()] = true
+	for _, label := range []string{TaskRunLabel, PipelineRunLabel} {
+		if name := pod.GetLabels()[label]; name != "" {
+			f.involvedObjects[name] = true
+		}
+	}
+}
+
+// OnKubernetesEvent shows the warning events of the BuildRun, its TaskRun or PipelineRun, and its
+// pods, and checks whether the informed pod is stuck in pending.
+func (f *Follower) OnKubernetesEvent(event *corev1.Event) error {
+	name := event.InvolvedObject.Name
+	if name != f.buildRun.Name && !f.involvedObjects[name] {
+		return nil
+	}
+	if event.Type == corev1.EventTypeWarning && !f.reportedEvents[event.UID] {
+		f.reportedEvents[event.UID] = true
+		f.Log(fmt.Sprintf("Warning: %s %q: %s: %s\n", event.InvolvedObject.Kind, name, event.Reason, event.Message))
+	}
+	if pod, ok := f.pods[name]; ok && event.InvolvedObject.Kind == "Pod" {
+		return f.checkPending(pod)
+	}
+	return nil
+}
+
+// pendingReason returns why the pod can't start, when it is unschedulable or the image of one of
+// its containers can't be pulled.
+func pendingReason(pod *corev1.Pod) (string, string) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			return c.Reason, c.Message
+		}
+	}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.State.Waiting == nil {
+			continue
+		}
+		switch status.State.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+			return status.State.Waiting.Reason, fmt.Sprintf("container %q: %s", status.Name, status.State.Waiting.Message)
+		}
+	}
+	return "", ""
+}
+
+// checkPending returns an error when the pod is unschedulable or can't pull an image for longer than
+// the pending grace period.
+func (f *Follower) checkPending(pod *corev1.Pod) error {
+	reason, message := pendingReason(pod)
+	if reason == "" || pod.Status.Phase != corev1.PodPending {
+		delete(f.pendingSince, pod.GetName())
+		return nil
+	}
+	if f.pendingGracePeriod <= 0 {
+		return nil
+	}
+	since, exists := f.pendingSince[pod.GetName()]
+	if !exists {
+		since = time.Now()
+		f.pendingSince[pod.GetName()] = since
+	}
+	// the pod is checked again once the grace period expires, in case no further events arrive
+	if remaining := f.pendingGracePeriod - time.Since(since); remaining > 0 {
+		f.pw.ScheduleRecheck(remaining)
+		return nil
+	}
+	f.Stop()
+	return fmt.Errorf("pod %q has been pending for more than %s because of %s: %s",
+		pod.GetName(), f.pendingGracePeriod, reason, message)
+}
+
+// OnRecheck checks again whether the pods being followed are stuck in pending, once the grace
+// period of one of them expires.
+func (f *Follower) OnRecheck() error {
+	for _, pod := range f.pods {
+		if err := f.checkPending(pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pipelineRunDone waits for either the BuildRun to be done, or for another pipeline task pod to be
+// running, after a pipeline task pod succeeded. When neither happens in time, it's assumed the
+// BuildRun still has tasks to execute.
+func (f *Follower) pipelineRunDone(pod *corev1.Pod) bool {
+	done := false
+	listOpts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", buildv1beta1.LabelBuildRun, f.buildRun.Name),
+	}
+	_ = wait.PollUntilContextTimeout(f.ctx, f.failPollInterval, f.failPollTimeout, true, func(ctx context.Context) (bool, error) {
+		br, err := f.buildClientset.ShipwrightV1beta1().BuildRuns(pod.GetNamespace()).Get(ctx, f.buildRun.Name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) || (err == nil && br.IsDone()) {
+			done = true
+			return true, nil
+		}
+		pods, err := f.clientset.CoreV1().Pods(pod.GetNamespace()).List(ctx, listOpts)
+		if err != nil {
+			return false, nil
+		}
+		for _, p := range pods.Items {
+			if p.Status.Phase == corev1.PodPending || p.Status.Phase == corev1.PodRunning {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	return done
+}
+
+// OnEventsError warns the events can't be watched, thus scheduling and image pull failures are only
+// reported once the BuildRun fails.
+func (f *Follower) OnEventsError(err error) {
+	f.logLock.Lock()
+	defer f.logLock.Unlock()
+	fmt.Fprintf(f.ioStreams.ErrOut, "Warning: the Kubernetes events can't be watched, pod scheduling and image pull "+
+		"failures are not reported while they happen: %s\n", err.Error())
+}
+
 // OnTimeout reacts to either the context or request timeout causing the pod watcher to exit
 func (f *Follower) OnTimeout(msg string) {
 	f.Log(fmt.Sprintf("BuildRun %q log following has stopped because: %q\n", f.buildRun.Name, msg))
@@ -276,6 +521,71 @@ func (f *Follower) Start(listOpts metav1.ListOptions) (*corev1.Pod, error) {
 	return f.WaitForCompletion()
 }
 
+// failureLogLines amount of log lines of the failed container shown on the failure summary.
+const failureLogLines int64 = 30
+
+// failureSummary describes the BuildRun failure details, the last log lines of the failed container
+// and the exit codes of every step, so the root cause is visible without further commands.
+func (f *Follower) failureSummary(br *buildv1beta1.BuildRun, pod *corev1.Pod) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\nFailure summary for BuildRun %q:\n", br.Name)
+
+	container := ""
+	if details := br.Status.FailureDetails; details != nil {
+		if details.Reason != "" {
+			fmt.Fprintf(&b, "  Reason:    %s\n", details.Reason)
+		}
+		if details.Message != "" {
+			fmt.Fprintf(&b, "  Message:   %s\n", details.Message)
+		}
+		if location := details.Location; location != nil && location.Container != "" {
+			container = location.Container
+			// for a PipelineRun the failure may have happened in a different task pod
+			if location.Pod != "" && location.Pod != pod.GetName() {
+				failed, err := f.clientset.CoreV1().Pods(pod.GetNamespace()).Get(f.ctx, location.Pod, metav1.GetOptions{})
+				if err == nil {
+					pod = failed
+				}
+			}
+		}
+	}
+	if container == "" {
+		container = failedContainer(pod)
+	}
+	if container != "" {
+		fmt.Fprintf(&b, "  Location:  pod %q, container %q\n", pod.GetName(), container)
+
+		opts := corev1.PodLogOptions{TailLines: ptr.To(failureLogLines)}
+		if logs, err := shputil.GetPodLogsWithOptions(f.ctx, f.clientset, *pod, container, opts); err == nil {
+			fmt.Fprintf(&b, "\nLast %d log lines of container %q:\n", failureLogLines, container)
+			fmt.Fprintln(&b, strings.TrimRight(logs, "\n"))
+		}
+	}
+
+	fmt.Fprintf(&b, "\nStep exit codes:\n")
+	writer := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		terminated := status.State.Terminated
+		if terminated == nil {
+			fmt.Fprintf(writer, "  %s\t-\tnot terminated\n", StepName(pod, status.Name))
+			continue
+		}
+		fmt.Fprintf(writer, "  %s\t%d\t%s\n", StepName(pod, status.Name), terminated.ExitCode, terminated.Reason)
+	}
+	_ = writer.Flush()
+	return b.String()
+}
+
+// failedContainer returns the name of the first container terminated with a non-zero exit code.
+func failedContainer(pod *corev1.Pod) string {
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+			return status.Name
+		}
+	}
+	return ""
+}
+
 func buildErrorMessage(br *buildv1beta1.BuildRun, pod *corev1.Pod) string {
 	failureDetails := br.Status.FailureDetails
 	if failureDetails == nil {
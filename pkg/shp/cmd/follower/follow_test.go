@@ -0,0 +1,155 @@
+package follower
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+	"github.com/shipwright-io/cli/pkg/shp/reactor"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestFollower(g *o.WithT, clientset *fake.Clientset, br *buildv1beta1.BuildRun) (*Follower, *bytes.Buffer) {
+	pw, err := reactor.NewPodWatcher(context.Background(), time.Minute, clientset, metav1.NamespaceDefault)
+	g.Expect(err).NotTo(o.HaveOccurred())
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	f := NewFollower(context.Background(), types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: br.Name},
+		&ioStreams, pw, clientset, shpfake.NewSimpleClientset(br))
+	return f, out
+}
+
+func TestPendingPodEvents(t *testing.T) {
+	g := o.NewWithT(t)
+
+	br := &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "br-xyz12-pod",
+			Labels:    map[string]string{TaskRunLabel: "br-xyz12"},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available",
+			}},
+		},
+	}
+	newEvent := func(uid, kind, name, reason string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{UID: types.UID(uid)},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name},
+			Type:           corev1.EventTypeWarning,
+			Reason:         reason,
+			Message:        "message of " + reason,
+		}
+	}
+
+	f, out := newTestFollower(g, fake.NewSimpleClientset(pod), br)
+	f.SetPendingGracePeriod(50 * time.Millisecond)
+
+	g.Expect(f.OnPodAdded(pod)).To(o.Succeed())
+	g.Expect(f.OnKubernetesEvent(newEvent("1", "TaskRun", "br-xyz12", "ExceededQuota"))).To(o.Succeed())
+	g.Expect(f.OnKubernetesEvent(newEvent("2", "Pod", "other-pod", "FailedMount"))).To(o.Succeed())
+	g.Expect(out.String()).To(o.ContainSubstring(`Warning: TaskRun "br-xyz12": ExceededQuota: message of ExceededQuota`))
+	g.Expect(out.String()).NotTo(o.ContainSubstring("FailedMount"))
+
+	g.Expect(f.OnKubernetesEvent(newEvent("3", "Pod", "br-xyz12-pod", "FailedScheduling"))).To(o.Succeed())
+	g.Expect(out.String()).To(o.ContainSubstring(`Warning: Pod "br-xyz12-pod": FailedScheduling`))
+
+	// without further events, the pod is checked again once the grace period expires
+	_, err := f.WaitForCompletion()
+	g.Expect(err).To(o.MatchError(
+		`pod "br-xyz12-pod" has been pending for more than 50ms because of Unschedulable: 0/3 nodes are available`))
+
+	// once scheduled, the pod is no longer considered stuck
+	scheduled := pod.DeepCopy()
+	scheduled.Status.Conditions = nil
+	scheduled.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "step-build",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+	}}
+	g.Expect(f.checkPending(scheduled)).To(o.Succeed())
+	g.Expect(f.pendingSince).NotTo(o.HaveKey("br-xyz12-pod"))
+}
+
+func TestFailureSummary(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br-pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "step-source-default"}, {Name: "step-build-and-push"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-source-default",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Completed"}},
+			}, {
+				Name:  "step-build-and-push",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 2}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		details  *buildv1beta1.FailureDetails
+		contains []string
+	}{{
+		name: "failure details",
+		details: &buildv1beta1.FailureDetails{
+			Reason:   "DockerfileNotFound",
+			Message:  "the Dockerfile does not exist",
+			Location: &buildv1beta1.Location{Pod: "br-pod", Container: "step-build-and-push"},
+		},
+		contains: []string{
+			"Reason:    DockerfileNotFound",
+			"Message:   the Dockerfile does not exist",
+			`Location:  pod "br-pod", container "step-build-and-push"`,
+			`Last 30 log lines of container "step-build-and-push":` + "\nfake logs",
+		},
+	}, {
+		name:     "failed container from the exit codes",
+		contains: []string{`Location:  pod "br-pod", container "step-build-and-push"`},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			br := &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "br"}}
+			br.Status.FailureDetails = tt.details
+
+			clientset := fake.NewSimpleClientset(pod)
+			f, _ := newTestFollower(g, clientset, br)
+
+			summary := f.failureSummary(br, pod)
+			for _, s := range tt.contains {
+				g.Expect(summary).To(o.ContainSubstring(s))
+			}
+			g.Expect(summary).To(o.MatchRegexp(`source-default +0 +Completed`))
+			g.Expect(summary).To(o.MatchRegexp(`build-and-push +2 +Error`))
+
+			// the log request is limited to the last lines
+			for _, action := range clientset.Actions() {
+				if action.GetSubresource() == "log" {
+					opts := action.(k8stesting.GenericAction).GetValue().(*corev1.PodLogOptions)
+					g.Expect(*opts.TailLines).To(o.Equal(failureLogLines))
+				}
+			}
+		})
+	}
+}
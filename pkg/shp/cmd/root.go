@@ -5,6 +5,7 @@ import (
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/shipwright-io/cli/pkg/shp/cmd/apply"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/build"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/buildrun"
 	"github.com/shipwright-io/cli/pkg/shp/cmd/buildstrategy"
@@ -30,6 +31,7 @@ func NewCmdSHP(ioStreams *genericclioptions.IOStreams) *cobra.Command {
 	rootCmd.AddCommand(buildrun.Command(p, ioStreams))
 	rootCmd.AddCommand(buildstrategy.Command(p, ioStreams))
 	rootCmd.AddCommand(clusterbuildstrategy.Command(p, ioStreams))
+	rootCmd.AddCommand(apply.Command(p, ioStreams))
 
 	visitCommands(rootCmd, reconfigureCommandWithSubcommand)
 
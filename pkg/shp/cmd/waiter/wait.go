@@ -0,0 +1,149 @@
+// Package waiter contains the logic to block until a BuildRun is completed, without streaming its
+// logs.
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ErrTimeout is returned when the BuildRun is not completed within the informed timeout.
+var ErrTimeout = errors.New("timed out waiting for the BuildRun to complete")
+
+// reconnectInterval is the time to wait before re-establishing a watch closed by the API server.
+var reconnectInterval = time.Second
+
+// Wait blocks until the BuildRun "Succeeded" condition is either true or false, or the timeout is
+// reached, a zero timeout waits indefinitely. An error is returned when the BuildRun has failed,
+// has been canceled or deleted, so the command exits with non-zero status. The watch is
+// re-established from the last seen resource version when the API server closes it, and the
+// BuildRun is retrieved again when that version is no longer available.
+func Wait(
+	ctx context.Context,
+	clientset buildclientset.Interface,
+	namespace string,
+	name string,
+	timeout time.Duration,
+) (*buildv1beta1.BuildRun, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	buildRuns := clientset.ShipwrightV1beta1().BuildRuns(namespace)
+	var br *buildv1beta1.BuildRun
+	relist := true
+	for {
+		if relist {
+			current, err := buildRuns.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return br, contextError(ctx, name, err)
+			}
+			br = current
+			if br.IsDone() {
+				return br, Outcome(br)
+			}
+			relist = false
+		}
+
+		w, err := buildRuns.Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: br.GetResourceVersion(),
+		})
+		if err != nil {
+			if !isExpired(err) {
+				return br, contextError(ctx, name, err)
+			}
+			relist = true
+			continue
+		}
+		br, relist, err = watchUntilDone(ctx, w, br)
+		w.Stop()
+		if err != nil || br.IsDone() {
+			return br, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return br, contextError(ctx, name, ctx.Err())
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+// watchUntilDone consumes the watch events until the BuildRun is done, returning the last seen
+// BuildRun. When the watch is closed, or its resource version has expired, the BuildRun is not done
+// and no error is returned, relist indicates the BuildRun must be retrieved again.
+func watchUntilDone(
+	ctx context.Context,
+	w watch.Interface,
+	br *buildv1beta1.BuildRun,
+) (*buildv1beta1.BuildRun, bool, error) {
+	name := br.GetName()
+	for {
+		select {
+		case <-ctx.Done():
+			return br, false, contextError(ctx, name, ctx.Err())
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return br, false, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				if err := kerrors.FromObject(event.Object); !isExpired(err) {
+					return br, false, err
+				}
+				return br, true, nil
+			case watch.Deleted:
+				return br, false, fmt.Errorf("BuildRun %q has been deleted", name)
+			}
+			updated, ok := event.Object.(*buildv1beta1.BuildRun)
+			if !ok || updated.GetName() != name {
+				continue
+			}
+			br = updated
+			if br.IsDone() {
+				return br, false, Outcome(br)
+			}
+		}
+	}
+}
+
+// isExpired checks if the error means the informed resource version is no longer available.
+func isExpired(err error) bool {
+	return kerrors.IsResourceExpired(err) || kerrors.IsGone(err)
+}
+
+// contextError returns ErrTimeout when the context deadline is exceeded, or the informed error.
+func contextError(ctx context.Context, name string, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %q", ErrTimeout, name)
+	}
+	return err
+}
+
+// Outcome returns an error describing why the BuildRun did not succeed, or nil when it did.
+func Outcome(br *buildv1beta1.BuildRun) error {
+	if br.IsSuccessful() {
+		return nil
+	}
+	if br.IsCanceled() {
+		return fmt.Errorf("BuildRun %q has been canceled", br.GetName())
+	}
+	condition := br.Status.GetCondition(buildv1beta1.Succeeded)
+	if condition == nil {
+		return fmt.Errorf("BuildRun %q is not completed", br.GetName())
+	}
+	return fmt.Errorf("BuildRun %q has failed: %s: %s", br.GetName(), condition.Reason, condition.Message)
+}
@@ -0,0 +1,184 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	shpfake "github.com/shipwright-io/build/pkg/client/clientset/versioned/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestWait(t *testing.T) {
+	newBuildRun := func(status corev1.ConditionStatus, reason string) *buildv1beta1.BuildRun {
+		br := &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "br"}}
+		if status != "" {
+			br.Status.Conditions = buildv1beta1.Conditions{{
+				Type:    buildv1beta1.Succeeded,
+				Status:  status,
+				Reason:  reason,
+				Message: "message",
+			}}
+		}
+		return br
+	}
+	canceled := newBuildRun(corev1.ConditionFalse, "BuildRunCanceled")
+	canceled.Spec.State = ptr.To(buildv1beta1.BuildRunRequestedState(buildv1beta1.BuildRunStateCancel))
+
+	tests := []struct {
+		name    string
+		current *buildv1beta1.BuildRun
+		events  []watch.Event
+		timeout time.Duration
+		err     string
+	}{{
+		name:    "already succeeded",
+		current: newBuildRun(corev1.ConditionTrue, "Succeeded"),
+	}, {
+		name:    "already failed",
+		current: newBuildRun(corev1.ConditionFalse, "Failed"),
+		err:     `BuildRun "br" has failed: Failed: message`,
+	}, {
+		name:    "succeeds while waiting",
+		current: newBuildRun("", ""),
+		events: []watch.Event{
+			{Type: watch.Modified, Object: newBuildRun(corev1.ConditionUnknown, "Running")},
+			{Type: watch.Modified, Object: newBuildRun(corev1.ConditionTrue, "Succeeded")},
+		},
+	}, {
+		name:    "fails while waiting",
+		current: newBuildRun(corev1.ConditionUnknown, "Pending"),
+		events: []watch.Event{
+			{Type: watch.Modified, Object: newBuildRun(corev1.ConditionFalse, "BuildRunTimeout")},
+		},
+		err: `BuildRun "br" has failed: BuildRunTimeout: message`,
+	}, {
+		name:    "canceled while waiting",
+		current: newBuildRun(corev1.ConditionUnknown, "Running"),
+		events:  []watch.Event{{Type: watch.Modified, Object: canceled}},
+		err:     `BuildRun "br" has been canceled`,
+	}, {
+		name:    "deleted while waiting",
+		current: newBuildRun(corev1.ConditionUnknown, "Running"),
+		events:  []watch.Event{{Type: watch.Deleted, Object: newBuildRun(corev1.ConditionUnknown, "Running")}},
+		err:     `BuildRun "br" has been deleted`,
+	}, {
+		name:    "timeout",
+		current: newBuildRun(corev1.ConditionUnknown, "Running"),
+		timeout: 10 * time.Millisecond,
+		err:     `timed out waiting for the BuildRun to complete: "br"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			watcher := watch.NewFakeWithChanSize(len(tt.events), false)
+			for _, event := range tt.events {
+				watcher.Action(event.Type, event.Object)
+			}
+			clientset := shpfake.NewSimpleClientset(tt.current)
+			clientset.PrependWatchReactor("buildruns", func(k8stesting.Action) (bool, watch.Interface, error) {
+				return true, watcher, nil
+			})
+
+			_, err := Wait(context.Background(), clientset, "default", "br", tt.timeout)
+			if tt.err == "" {
+				g.Expect(err).NotTo(o.HaveOccurred())
+				return
+			}
+			g.Expect(err).To(o.MatchError(tt.err))
+			if tt.timeout > 0 {
+				g.Expect(errors.Is(err, ErrTimeout)).To(o.BeTrue())
+			}
+		})
+	}
+}
+
+func TestWaitReconnect(t *testing.T) {
+	defer func(interval time.Duration) { reconnectInterval = interval }(reconnectInterval)
+	reconnectInterval = time.Millisecond
+	newBuildRun := func(status corev1.ConditionStatus, resourceVersion string) *buildv1beta1.BuildRun {
+		return &buildv1beta1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "br", ResourceVersion: resourceVersion},
+			Status: buildv1beta1.BuildRunStatus{Conditions: buildv1beta1.Conditions{{
+				Type:   buildv1beta1.Succeeded,
+				Status: status,
+			}}},
+		}
+	}
+	expired := &metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusGone,
+		Reason: metav1.StatusReasonExpired,
+	}
+
+	tests := []struct {
+		name    string
+		current *buildv1beta1.BuildRun
+		relist  *buildv1beta1.BuildRun
+		first   []watch.Event
+		rv      string
+	}{{
+		name:    "watch closed by the API server",
+		current: newBuildRun(corev1.ConditionUnknown, "1"),
+		first:   []watch.Event{{Type: watch.Modified, Object: newBuildRun(corev1.ConditionUnknown, "2")}},
+		rv:      "2",
+	}, {
+		name:    "resource version expired",
+		current: newBuildRun(corev1.ConditionUnknown, "1"),
+		relist:  newBuildRun(corev1.ConditionUnknown, "5"),
+		first:   []watch.Event{{Type: watch.Error, Object: expired}},
+		rv:      "5",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			first := watch.NewFakeWithChanSize(len(tt.first), false)
+			for _, event := range tt.first {
+				first.Action(event.Type, event.Object)
+			}
+			// the API server closes the first watch, the second one brings the BuildRun to completion
+			first.Stop()
+			second := watch.NewFakeWithChanSize(1, false)
+			second.Modify(newBuildRun(corev1.ConditionTrue, "6"))
+
+			clientset := shpfake.NewSimpleClientset(tt.current)
+			if tt.relist != nil {
+				gets := 0
+				clientset.PrependReactor("get", "buildruns", func(k8stesting.Action) (bool, runtime.Object, error) {
+					if gets++; gets > 1 {
+						return true, tt.relist, nil
+					}
+					return false, nil, nil
+				})
+			}
+			var resourceVersions []string
+			clientset.PrependWatchReactor("buildruns", func(action k8stesting.Action) (bool, watch.Interface, error) {
+				restrictions := action.(k8stesting.WatchAction).GetWatchRestrictions()
+				resourceVersions = append(resourceVersions, restrictions.ResourceVersion)
+				if len(resourceVersions) == 1 {
+					return true, first, nil
+				}
+				return true, second, nil
+			})
+
+			br, err := Wait(context.Background(), clientset, "default", "br", time.Minute)
+			g.Expect(err).NotTo(o.HaveOccurred())
+			g.Expect(br.IsSuccessful()).To(o.BeTrue())
+			g.Expect(resourceVersions).To(o.Equal([]string{"1", tt.rv}))
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package describe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/cli/pkg/shp/flags"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+)
+
+// none is shown when a given attribute is not set.
+const none = "<none>"
+
+// Tabbed executes the informed function against a PrefixWriter backed by a tabwriter, in order to
+// have the sections aligned, and writes the result on the informed writer.
+func Tabbed(out io.Writer, fn func(w kdescribe.PrefixWriter) error) error {
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 8, 2, ' ', 0)
+	if err := fn(kdescribe.NewPrefixWriter(tw)); err != nil {
+		return err
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// StringOrNone returns the informed string pointer value, or "<none>" when nil or empty.
+func StringOrNone(s *string) string {
+	if s == nil || *s == "" {
+		return none
+	}
+	return *s
+}
+
+// Timestamp renders the informed time in RFC3339 format, followed by the time elapsed since then.
+func Timestamp(t *metav1.Time) string {
+	if t == nil || t.IsZero() {
+		return none
+	}
+	return fmt.Sprintf("%s (%s ago)", t.UTC().Format(time.RFC3339), duration.HumanDuration(time.Since(t.Time)))
+}
+
+// ObjectMeta writes the common metadata attributes of a Kubernetes object.
+func ObjectMeta(w kdescribe.PrefixWriter, meta metav1.ObjectMeta) {
+	w.Write(kdescribe.LEVEL_0, "Name:\t%s\n", meta.Name)
+	if meta.Namespace != "" {
+		w.Write(kdescribe.LEVEL_0, "Namespace:\t%s\n", meta.Namespace)
+	}
+	Map(w, kdescribe.LEVEL_0, "Labels", meta.Labels)
+	Map(w, kdescribe.LEVEL_0, "Annotations", meta.Annotations)
+	w.Write(kdescribe.LEVEL_0, "Created:\t%s\n", Timestamp(&meta.CreationTimestamp))
+}
+
+// Map writes the informed map as sorted "key=value" entries, one per line.
+func Map(w kdescribe.PrefixWriter, level int, title string, m map[string]string) {
+	if len(m) == 0 {
+		w.Write(level, "%s:\t%s\n", title, none)
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		if i == 0 {
+			w.Write(level, "%s:\t%s=%s\n", title, k, m[k])
+			continue
+		}
+		w.Write(level, "\t%s=%s\n", k, m[k])
+	}
+}
+
+// Volumes writes the informed strategy volume overrides, one per line, or "<none>" when empty.
+func Volumes(w kdescribe.PrefixWriter, level int, volumes []buildv1beta1.BuildVolume) {
+	if len(volumes) == 0 {
+		w.Write(level, "Volumes:\t%s\n", none)
+		return
+	}
+	for i, v := range volumes {
+		s := flags.FormatBuildVolume(v)
+		if i == 0 {
+			w.Write(level, "Volumes:\t%s\n", s)
+			continue
+		}
+		w.Write(level, "\t%s\n", s)
+	}
+}
+
+// Tolerations writes the informed tolerations, one per line, using the "key[=value]:Effect[:seconds]"
+// notation, or "<none>" when empty.
+func Tolerations(w kdescribe.PrefixWriter, level int, tolerations []corev1.Toleration) {
+	if len(tolerations) == 0 {
+		w.Write(level, "Tolerations:\t%s\n", none)
+		return
+	}
+	for i, t := range tolerations {
+		s := flags.FormatToleration(t)
+		if i == 0 {
+			w.Write(level, "Tolerations:\t%s\n", s)
+			continue
+		}
+		w.Write(level, "\t%s\n", s)
+	}
+}
+
+// singleValue renders the value of a parameter, which can be an inline value or a reference.
+func singleValue(v *buildv1beta1.SingleValue) string {
+	switch {
+	case v == nil:
+		return ""
+	case v.Value != nil:
+		return *v.Value
+	case v.ConfigMapValue != nil:
+		return fmt.Sprintf("<configmap %s, key %s>", v.ConfigMapValue.Name, v.ConfigMapValue.Key)
+	case v.SecretValue != nil:
+		return fmt.Sprintf("<secret %s, key %s>", v.SecretValue.Name, v.SecretValue.Key)
+	default:
+		return ""
+	}
+}
+
+// ParamValues writes the parameter values, array parameters are shown between brackets.
+func ParamValues(w kdescribe.PrefixWriter, level int, paramValues []buildv1beta1.ParamValue) {
+	if len(paramValues) == 0 {
+		w.Write(level, "Param Values:\t%s\n", none)
+		return
+	}
+	w.Write(level, "Param Values:\n")
+	for _, p := range paramValues {
+		if p.SingleValue != nil {
+			w.Write(level+1, "%s:\t%s\n", p.Name, singleValue(p.SingleValue))
+			continue
+		}
+		values := make([]string, 0, len(p.Values))
+		for i := range p.Values {
+			values = append(values, singleValue(&p.Values[i]))
+		}
+		w.Write(level+1, "%s:\t[%s]\n", p.Name, strings.Join(values, ", "))
+	}
+}
+
+// Env writes the environment variables, references to other objects are not resolved.
+func Env(w kdescribe.PrefixWriter, level int, envs []corev1.EnvVar) {
+	if len(envs) == 0 {
+		w.Write(level, "Env:\t%s\n", none)
+		return
+	}
+	w.Write(level, "Env:\n")
+	for _, e := range envs {
+		value := e.Value
+		if e.ValueFrom != nil {
+			switch {
+			case e.ValueFrom.SecretKeyRef != nil:
+				value = fmt.Sprintf("<secret %s, key %s>", e.ValueFrom.SecretKeyRef.Name, e.ValueFrom.SecretKeyRef.Key)
+			case e.ValueFrom.ConfigMapKeyRef != nil:
+				value = fmt.Sprintf("<configmap %s, key %s>", e.ValueFrom.ConfigMapKeyRef.Name, e.ValueFrom.ConfigMapKeyRef.Key)
+			case e.ValueFrom.FieldRef != nil:
+				value = fmt.Sprintf("<field %s>", e.ValueFrom.FieldRef.FieldPath)
+			}
+		}
+		w.Write(level+1, "%s:\t%s\n", e.Name, value)
+	}
+}
+
+// Image writes the Shipwright image attributes, used for the output image.
+func Image(w kdescribe.PrefixWriter, level int, title string, image *buildv1beta1.Image) {
+	if image == nil {
+		w.Write(level, "%s:\t%s\n", title, none)
+		return
+	}
+	w.Write(level, "%s:\n", title)
+	w.Write(level+1, "Image:\t%s\n", image.Image)
+	w.Write(level+1, "Push Secret:\t%s\n", StringOrNone(image.PushSecret))
+	if image.Insecure != nil {
+		w.Write(level+1, "Insecure:\t%t\n", *image.Insecure)
+	}
+	if image.Timestamp != nil {
+		w.Write(level+1, "Timestamp:\t%s\n", *image.Timestamp)
+	}
+	Map(w, level+1, "Labels", image.Labels)
+	Map(w, level+1, "Annotations", image.Annotations)
+}
+
+// Source writes the Build source attributes.
+func Source(w kdescribe.PrefixWriter, level int, source *buildv1beta1.Source) {
+	if source == nil {
+		w.Write(level, "Source:\t%s\n", none)
+		return
+	}
+	w.Write(level, "Source:\n")
+	w.Write(level+1, "Type:\t%s\n", source.Type)
+	switch {
+	case source.Git != nil:
+		w.Write(level+1, "URL:\t%s\n", source.Git.URL)
+		w.Write(level+1, "Revision:\t%s\n", StringOrNone(source.Git.Revision))
+		w.Write(level+1, "Clone Secret:\t%s\n", StringOrNone(source.Git.CloneSecret))
+	case source.OCIArtifact != nil:
+		w.Write(level+1, "Image:\t%s\n", source.OCIArtifact.Image)
+		w.Write(level+1, "Pull Secret:\t%s\n", StringOrNone(source.OCIArtifact.PullSecret))
+		if source.OCIArtifact.Prune != nil {
+			w.Write(level+1, "Prune:\t%s\n", *source.OCIArtifact.Prune)
+		}
+	}
+	if source.Local != nil && source.Local.Timeout != nil {
+		w.Write(level+1, "Local Timeout:\t%s\n", source.Local.Timeout.Duration)
+	}
+	w.Write(level+1, "Context Dir:\t%s\n", StringOrNone(source.ContextDir))
+}
+
+// Duration renders the informed duration, or "<none>" when not set.
+func Duration(d *metav1.Duration) string {
+	if d == nil || d.Duration == 0 {
+		return none
+	}
+	return d.Duration.String()
+}
+
+// BuildSpec writes all attributes of the informed BuildSpec, the same layout is employed for the
+// Build resource and the effective BuildSpec recorded on the BuildRun status.
+func BuildSpec(w kdescribe.PrefixWriter, level int, spec *buildv1beta1.BuildSpec) {
+	w.Write(level, "Strategy:\n")
+	kind := buildv1beta1.NamespacedBuildStrategyKind
+	if spec.Strategy.Kind != nil {
+		kind = *spec.Strategy.Kind
+	}
+	w.Write(level+1, "Kind:\t%s\n", kind)
+	w.Write(level+1, "Name:\t%s\n", spec.Strategy.Name)
+	Source(w, level, spec.Source)
+	Image(w, level, "Output", &spec.Output)
+	ParamValues(w, level, spec.ParamValues)
+	Env(w, level, spec.Env)
+	w.Write(level, "Timeout:\t%s\n", Duration(spec.Timeout))
+	if spec.Retention != nil {
+		w.Write(level, "Retention:\n")
+		if spec.Retention.FailedLimit != nil {
+			w.Write(level+1, "Failed Limit:\t%d\n", *spec.Retention.FailedLimit)
+		}
+		if spec.Retention.SucceededLimit != nil {
+			w.Write(level+1, "Succeeded Limit:\t%d\n", *spec.Retention.SucceededLimit)
+		}
+		w.Write(level+1, "TTL After Failed:\t%s\n", Duration(spec.Retention.TTLAfterFailed))
+		w.Write(level+1, "TTL After Succeeded:\t%s\n", Duration(spec.Retention.TTLAfterSucceeded))
+	}
+	Volumes(w, level, spec.Volumes)
+	Map(w, level, "Node Selector", spec.NodeSelector)
+	Tolerations(w, level, spec.Tolerations)
+	w.Write(level, "Scheduler Name:\t%s\n", StringOrNone(spec.SchedulerName))
+	w.Write(level, "Runtime Class:\t%s\n", StringOrNone(spec.RuntimeClassName))
+}
+
+// InvolvedObject identifies an object, by kind and name, to which events are related to.
+type InvolvedObject struct {
+	Kind string
+	Name string
+}
+
+// Events lists the events related to the informed objects, skipping duplicated entries.
+func Events(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	namespace string,
+	objects ...InvolvedObject,
+) (*corev1.EventList, error) {
+	events := &corev1.EventList{}
+	seen := map[string]bool{}
+	for _, obj := range objects {
+		if obj.Name == "" {
+			continue
+		}
+		selector := fields.Set{
+			"involvedObject.kind": obj.Kind,
+			"involvedObject.name": obj.Name,
+		}.AsSelector().String()
+		el, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range el.Items {
+			if seen[e.Name] {
+				continue
+			}
+			seen[e.Name] = true
+			events.Items = append(events.Items, e)
+		}
+	}
+	return events, nil
+}
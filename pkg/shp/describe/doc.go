@@ -0,0 +1,3 @@
+// Package describe contains the helpers shared by the "describe" sub-commands, rendering Shipwright
+// resources in a human-readable sectioned layout, in the same fashion as "kubectl describe".
+package describe
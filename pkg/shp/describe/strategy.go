@@ -0,0 +1,119 @@
+package describe
+
+import (
+	"fmt"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+)
+
+// quantityOrNone renders the informed resource quantity, or "<none>" when not set.
+func quantityOrNone(list corev1.ResourceList, name corev1.ResourceName) string {
+	if q, ok := list[name]; ok {
+		return q.String()
+	}
+	return none
+}
+
+// VolumeSourceType returns a short description of the informed volume source type, for instance
+// "emptyDir" or "configMap:name".
+func VolumeSourceType(source corev1.VolumeSource) string {
+	switch {
+	case source.EmptyDir != nil:
+		return "emptyDir"
+	case source.ConfigMap != nil:
+		return fmt.Sprintf("configMap:%s", source.ConfigMap.Name)
+	case source.Secret != nil:
+		return fmt.Sprintf("secret:%s", source.Secret.SecretName)
+	case source.PersistentVolumeClaim != nil:
+		return fmt.Sprintf("pvc:%s", source.PersistentVolumeClaim.ClaimName)
+	case source.HostPath != nil:
+		return fmt.Sprintf("hostPath:%s", source.HostPath.Path)
+	case source.CSI != nil:
+		return fmt.Sprintf("csi:%s", source.CSI.Driver)
+	case source.Projected != nil:
+		return "projected"
+	default:
+		return none
+	}
+}
+
+// parameterDefault renders the default value of a strategy parameter, array defaults are shown
+// between brackets.
+func parameterDefault(p buildv1beta1.Parameter) string {
+	switch {
+	case p.Default != nil:
+		return *p.Default
+	case p.Defaults != nil:
+		return fmt.Sprintf("[%s]", strings.Join(*p.Defaults, ", "))
+	default:
+		return none
+	}
+}
+
+// BuildStrategySpec writes the steps, parameters, volumes and security context of a BuildStrategy
+// or ClusterBuildStrategy.
+func BuildStrategySpec(w kdescribe.PrefixWriter, spec *buildv1beta1.BuildStrategySpec) {
+	if spec.SecurityContext != nil {
+		w.Write(kdescribe.LEVEL_0, "Security Context:\n")
+		w.Write(kdescribe.LEVEL_1, "Run As User:\t%d\n", spec.SecurityContext.RunAsUser)
+		w.Write(kdescribe.LEVEL_1, "Run As Group:\t%d\n", spec.SecurityContext.RunAsGroup)
+	} else {
+		w.Write(kdescribe.LEVEL_0, "Security Context:\t%s\n", none)
+	}
+
+	if len(spec.Steps) == 0 {
+		w.Write(kdescribe.LEVEL_0, "Steps:\t%s\n", none)
+	} else {
+		w.Write(kdescribe.LEVEL_0, "Steps:\n")
+		w.Write(kdescribe.LEVEL_1, "Name\tImage\tCPU Request\tMemory Request\tCPU Limit\tMemory Limit\n")
+		w.Write(kdescribe.LEVEL_1, "----\t-----\t-----------\t--------------\t---------\t------------\n")
+		for _, step := range spec.Steps {
+			w.Write(kdescribe.LEVEL_1, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				step.Name,
+				step.Image,
+				quantityOrNone(step.Resources.Requests, corev1.ResourceCPU),
+				quantityOrNone(step.Resources.Requests, corev1.ResourceMemory),
+				quantityOrNone(step.Resources.Limits, corev1.ResourceCPU),
+				quantityOrNone(step.Resources.Limits, corev1.ResourceMemory),
+			)
+		}
+	}
+
+	if len(spec.Parameters) == 0 {
+		w.Write(kdescribe.LEVEL_0, "Parameters:\t%s\n", none)
+	} else {
+		w.Write(kdescribe.LEVEL_0, "Parameters:\n")
+		w.Write(kdescribe.LEVEL_1, "Name\tType\tDefault\tDescription\n")
+		w.Write(kdescribe.LEVEL_1, "----\t----\t-------\t-----------\n")
+		for _, p := range spec.Parameters {
+			paramType := p.Type
+			if paramType == "" {
+				paramType = buildv1beta1.ParameterTypeString
+			}
+			w.Write(kdescribe.LEVEL_1, "%s\t%s\t%s\t%s\n", p.Name, paramType, parameterDefault(p), p.Description)
+		}
+	}
+
+	if len(spec.Volumes) == 0 {
+		w.Write(kdescribe.LEVEL_0, "Volumes:\t%s\n", none)
+		return
+	}
+	w.Write(kdescribe.LEVEL_0, "Volumes:\n")
+	w.Write(kdescribe.LEVEL_1, "Name\tOverridable\tSource\tDescription\n")
+	w.Write(kdescribe.LEVEL_1, "----\t-----------\t------\t-----------\n")
+	for _, v := range spec.Volumes {
+		overridable := false
+		if v.Overridable != nil {
+			overridable = *v.Overridable
+		}
+		description := ""
+		if v.Description != nil {
+			description = *v.Description
+		}
+		w.Write(kdescribe.LEVEL_1, "%s\t%t\t%s\t%s\n", v.Name, overridable, VolumeSourceType(v.VolumeSource), description)
+	}
+}
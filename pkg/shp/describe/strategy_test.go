@@ -0,0 +1,62 @@
+package describe
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	kdescribe "k8s.io/kubectl/pkg/describe"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildStrategySpec(t *testing.T) {
+	spec := &buildv1beta1.BuildStrategySpec{
+		Steps: []buildv1beta1.Step{{
+			Name:  "build-and-push",
+			Image: "quay.io/buildah/stable",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}},
+		Parameters: []buildv1beta1.Parameter{{
+			Name:        "storage-driver",
+			Description: "The storage driver to use",
+			Default:     ptr.To("vfs"),
+		}, {
+			Name:     "build-args",
+			Type:     buildv1beta1.ParameterTypeArray,
+			Defaults: &[]string{"a=b", "c=d"},
+		}},
+		Volumes: []buildv1beta1.BuildStrategyVolume{{
+			Name:         "cache",
+			Overridable:  ptr.To(true),
+			Description:  ptr.To("build cache"),
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}},
+	}
+
+	out := &bytes.Buffer{}
+	if err := Tabbed(out, func(w kdescribe.PrefixWriter) error {
+		BuildStrategySpec(w, spec)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"Security Context:  <none>",
+		"build-and-push", "quay.io/buildah/stable", "250m", "1Gi",
+		"storage-driver", "string", "vfs", "The storage driver to use",
+		"build-args", "array", "[a=b, c=d]",
+		"cache", "true", "emptyDir", "build cache",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q on output:\n%s", expected, out.String())
+		}
+	}
+}
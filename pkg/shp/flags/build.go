@@ -4,6 +4,7 @@ import (
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/spf13/pflag"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -36,6 +37,9 @@ func BuildSpecFromFlags(flags *pflag.FlagSet) (*buildv1beta1.BuildSpec, *string,
 			PushSecret:  ptr.To(""),
 			Labels:      map[string]string{},
 			Annotations: map[string]string{},
+			Timestamp:   ptr.To(""),
+
+			VulnerabilityScan: newVulnerabilityScanOptions(),
 		},
 		Timeout: &metav1.Duration{},
 		Retention: &buildv1beta1.BuildRetention{
@@ -44,8 +48,11 @@ func BuildSpecFromFlags(flags *pflag.FlagSet) (*buildv1beta1.BuildSpec, *string,
 			TTLAfterFailed:    &metav1.Duration{},
 			TTLAfterSucceeded: &metav1.Duration{},
 		},
-		NodeSelector:  map[string]string{},
-		SchedulerName: ptr.To(""),
+		Volumes:          []buildv1beta1.BuildVolume{},
+		NodeSelector:     map[string]string{},
+		Tolerations:      []corev1.Toleration{},
+		SchedulerName:    ptr.To(""),
+		RuntimeClassName: ptr.To(""),
 	}
 
 	sourceFlags(flags, spec.Source)
@@ -56,9 +63,13 @@ func BuildSpecFromFlags(flags *pflag.FlagSet) (*buildv1beta1.BuildSpec, *string,
 	paramValueFlag(flags, &spec.ParamValues)
 	imageLabelsFlags(flags, spec.Output.Labels)
 	imageAnnotationsFlags(flags, spec.Output.Annotations)
+	vulnerabilityScanFlags(flags, spec.Output.VulnerabilityScan)
 	buildRetentionFlags(flags, spec.Retention)
+	buildVolumesFlags(flags, &spec.Volumes)
 	buildNodeSelectorFlags(flags, spec.NodeSelector)
+	buildTolerationsFlags(flags, &spec.Tolerations)
 	buildSchedulerNameFlag(flags, spec.SchedulerName)
+	buildRuntimeClassNameFlag(flags, spec.RuntimeClassName)
 	var dockerfile, builderImage string
 	dockerfileFlags(flags, &dockerfile)
 	builderImageFlag(flags, &builderImage)
@@ -119,6 +130,10 @@ func SanitizeBuildSpec(b *buildv1beta1.BuildSpec) {
 	if b.Output.Insecure != nil && !*b.Output.Insecure {
 		b.Output.Insecure = nil
 	}
+	if b.Output.Timestamp != nil && *b.Output.Timestamp == "" {
+		b.Output.Timestamp = nil
+	}
+	b.Output.VulnerabilityScan = sanitizeVulnerabilityScan(b.Output.VulnerabilityScan)
 	if b.Retention != nil {
 		if b.Retention.FailedLimit != nil && *b.Retention.FailedLimit == 65535 {
 			b.Retention.FailedLimit = nil
@@ -136,7 +151,16 @@ func SanitizeBuildSpec(b *buildv1beta1.BuildSpec) {
 			b.Retention = nil
 		}
 	}
+	if len(b.Volumes) == 0 {
+		b.Volumes = nil
+	}
+	if len(b.Tolerations) == 0 {
+		b.Tolerations = nil
+	}
 	if b.SchedulerName != nil && *b.SchedulerName == "" {
 		b.SchedulerName = nil
 	}
+	if b.RuntimeClassName != nil && *b.RuntimeClassName == "" {
+		b.RuntimeClassName = nil
+	}
 }
@@ -8,6 +8,7 @@ import (
 	o "github.com/onsi/gomega"
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -41,6 +42,17 @@ func TestBuildSpecFromFlags(t *testing.T) {
 			Insecure:    ptr.To(false),
 			Labels:      map[string]string{},
 			Annotations: map[string]string{},
+			Timestamp:   ptr.To(buildv1beta1.OutputImageSourceTimestamp),
+
+			VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{
+				Enabled:       true,
+				FailOnFinding: true,
+				Ignore: &buildv1beta1.VulnerabilityIgnoreOptions{
+					ID:       []string{"CVE-2024-0001", "CVE-2024-0002"},
+					Severity: ptr.To(buildv1beta1.IgnoredLow),
+					Unfixed:  ptr.To(true),
+				},
+			},
 		},
 		Timeout: &metav1.Duration{
 			Duration: 1 * time.Second,
@@ -55,8 +67,21 @@ func TestBuildSpecFromFlags(t *testing.T) {
 				Duration: 30 * time.Minute,
 			},
 		},
-		NodeSelector:  map[string]string{"kubernetes.io/hostname": "worker-1"},
-		SchedulerName: ptr.To("dolphinscheduler"),
+		Volumes: []buildv1beta1.BuildVolume{{
+			Name: "cache",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "build-cache"},
+			},
+		}},
+		NodeSelector: map[string]string{"kubernetes.io/hostname": "worker-1"},
+		Tolerations: []corev1.Toleration{{
+			Key:      "dedicated",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "builds",
+			Effect:   corev1.TaintEffectNoSchedule,
+		}},
+		SchedulerName:    ptr.To("dolphinscheduler"),
+		RuntimeClassName: ptr.To("kata"),
 	}
 
 	cmd := &cobra.Command{}
@@ -114,6 +139,17 @@ func TestBuildSpecFromFlags(t *testing.T) {
 		err = flags.Set(OutputInsecureFlag, strconv.FormatBool(*expected.Output.Insecure))
 		g.Expect(err).To(o.BeNil())
 
+		for flag, value := range map[string]string{
+			OutputVulnScanFlag:           "true",
+			OutputVulnFailOnFindingFlag:  "true",
+			OutputVulnIgnoreIDFlag:       "CVE-2024-0001,CVE-2024-0002",
+			OutputVulnIgnoreSeverityFlag: "low",
+			OutputVulnIgnoreUnfixedFlag:  "true",
+			OutputTimestampFlag:          *expected.Output.Timestamp,
+		} {
+			g.Expect(flags.Set(flag, value)).To(o.Succeed())
+		}
+
 		g.Expect(expected.Output).To(o.Equal(spec.Output), "spec.output")
 	})
 
@@ -124,6 +160,20 @@ func TestBuildSpecFromFlags(t *testing.T) {
 		g.Expect(expected.NodeSelector).To(o.Equal(spec.NodeSelector), ".spec.nodeSelector")
 	})
 
+	t.Run(".spec.volumes", func(_ *testing.T) {
+		err := flags.Set(VolumeFlag, "cache=pvc:build-cache")
+		g.Expect(err).To(o.BeNil())
+
+		g.Expect(expected.Volumes).To(o.Equal(spec.Volumes), "spec.volumes")
+	})
+
+	t.Run(".spec.tolerations", func(_ *testing.T) {
+		err := flags.Set(TolerationFlag, "dedicated=builds:NoSchedule")
+		g.Expect(err).To(o.BeNil())
+
+		g.Expect(expected.Tolerations).To(o.Equal(spec.Tolerations), "spec.tolerations")
+	})
+
 	t.Run(".spec.schedulerName", func(_ *testing.T) {
 		err := flags.Set(SchedulerNameFlag, *expected.SchedulerName)
 		g.Expect(err).To(o.BeNil())
@@ -131,6 +181,13 @@ func TestBuildSpecFromFlags(t *testing.T) {
 		g.Expect(expected.SchedulerName).To(o.Equal(spec.SchedulerName), "spec.schedulerName")
 	})
 
+	t.Run(".spec.runtimeClassName", func(_ *testing.T) {
+		err := flags.Set(RuntimeClassNameFlag, *expected.RuntimeClassName)
+		g.Expect(err).To(o.BeNil())
+
+		g.Expect(expected.RuntimeClassName).To(o.Equal(spec.RuntimeClassName), "spec.runtimeClassName")
+	})
+
 	t.Run(".spec.timeout", func(_ *testing.T) {
 		err := flags.Set(TimeoutFlag, expected.Timeout.Duration.String())
 		g.Expect(err).To(o.BeNil())
@@ -264,6 +321,65 @@ func TestSanitizeBuildSpec(t *testing.T) {
 					Image: "some",
 				},
 			},
+		}, {
+			name: "should clean-up empty vulnerability scan options",
+			in: buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{
+					Image: "some",
+					VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{
+						Ignore: &buildv1beta1.VulnerabilityIgnoreOptions{
+							Severity: ptr.To(buildv1beta1.IgnoredVulnerabilitySeverity("")),
+							Unfixed:  ptr.To(false),
+						},
+					},
+				},
+			},
+			out: buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{
+					Image: "some",
+				},
+			},
+		}, {
+			name: "should keep the enabled vulnerability scan without ignore options",
+			in: buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{
+					Image: "some",
+					VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{
+						Enabled: true,
+						Ignore: &buildv1beta1.VulnerabilityIgnoreOptions{
+							Severity: ptr.To(buildv1beta1.IgnoredVulnerabilitySeverity("")),
+							Unfixed:  ptr.To(false),
+						},
+					},
+				},
+			},
+			out: buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{
+					Image:             "some",
+					VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{Enabled: true},
+				},
+			},
+		}, {
+			name: "should clean-up an empty output timestamp",
+			in: buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{
+					Image:     "some",
+					Timestamp: ptr.To(""),
+				},
+			},
+			out: buildv1beta1.BuildSpec{
+				Output: buildv1beta1.Image{
+					Image: "some",
+				},
+			},
+		}, {
+			name: "should clean-up empty volumes, tolerations and runtime class",
+			in: buildv1beta1.BuildSpec{
+				Volumes:          []buildv1beta1.BuildVolume{},
+				Tolerations:      []corev1.Toleration{},
+				RuntimeClassName: ptr.To(""),
+			},
+			out: buildv1beta1.BuildSpec{},
 		}, {
 			name: "should not clean-up a true output insecure",
 			in: buildv1beta1.BuildSpec{
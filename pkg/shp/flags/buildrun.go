@@ -22,13 +22,18 @@ func BuildRunSpecFromFlags(flags *pflag.FlagSet) *buildv1beta1.BuildRunSpec {
 			Insecure:    ptr.To(false),
 			Labels:      map[string]string{},
 			Annotations: map[string]string{},
+			Timestamp:   ptr.To(""),
+
+			VulnerabilityScan: newVulnerabilityScanOptions(),
 		},
 		Env: []corev1.EnvVar{},
 		Retention: &buildv1beta1.BuildRunRetention{
 			TTLAfterFailed:    &metav1.Duration{},
 			TTLAfterSucceeded: &metav1.Duration{},
 		},
+		Volumes:          []buildv1beta1.BuildVolume{},
 		NodeSelector:     map[string]string{},
+		Tolerations:      []corev1.Toleration{},
 		SchedulerName:    ptr.To(""),
 		RuntimeClassName: ptr.To(""),
 	}
@@ -41,8 +46,11 @@ func BuildRunSpecFromFlags(flags *pflag.FlagSet) *buildv1beta1.BuildRunSpec {
 	paramValueFlag(flags, &spec.ParamValues)
 	imageLabelsFlags(flags, spec.Output.Labels)
 	imageAnnotationsFlags(flags, spec.Output.Annotations)
+	vulnerabilityScanFlags(flags, spec.Output.VulnerabilityScan)
 	buildRunRetentionFlags(flags, spec.Retention)
+	buildVolumesFlags(flags, &spec.Volumes)
 	buildNodeSelectorFlags(flags, spec.NodeSelector)
+	buildTolerationsFlags(flags, &spec.Tolerations)
 	buildSchedulerNameFlag(flags, spec.SchedulerName)
 	buildRuntimeClassNameFlag(flags, spec.RuntimeClassName)
 	return spec
@@ -66,7 +74,12 @@ func SanitizeBuildRunSpec(br *buildv1beta1.BuildRunSpec) {
 		if br.Output.Insecure != nil && !*br.Output.Insecure {
 			br.Output.Insecure = nil
 		}
-		if br.Output.Image == "" && br.Output.PushSecret == nil {
+		if br.Output.Timestamp != nil && *br.Output.Timestamp == "" {
+			br.Output.Timestamp = nil
+		}
+		br.Output.VulnerabilityScan = sanitizeVulnerabilityScan(br.Output.VulnerabilityScan)
+		if br.Output.Image == "" && br.Output.PushSecret == nil && br.Output.Timestamp == nil &&
+			br.Output.VulnerabilityScan == nil {
 			br.Output = nil
 		}
 	}
@@ -88,6 +101,12 @@ func SanitizeBuildRunSpec(br *buildv1beta1.BuildRunSpec) {
 			br.Retention = nil
 		}
 	}
+	if len(br.Volumes) == 0 {
+		br.Volumes = nil
+	}
+	if len(br.Tolerations) == 0 {
+		br.Tolerations = nil
+	}
 	if br.SchedulerName != nil && *br.SchedulerName == "" {
 		br.SchedulerName = nil
 	}
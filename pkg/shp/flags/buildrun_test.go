@@ -7,6 +7,7 @@ import (
 	o "github.com/onsi/gomega"
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -29,6 +30,17 @@ func TestBuildRunSpecFromFlags(t *testing.T) {
 			Insecure:    ptr.To(false),
 			Labels:      map[string]string{},
 			Annotations: map[string]string{},
+			Timestamp:   ptr.To("1691650396"),
+
+			VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{
+				Enabled:       true,
+				FailOnFinding: true,
+				Ignore: &buildv1beta1.VulnerabilityIgnoreOptions{
+					ID:       []string{"CVE-2024-0001", "CVE-2024-0002"},
+					Severity: ptr.To(buildv1beta1.IgnoredLow),
+					Unfixed:  ptr.To(true),
+				},
+			},
 		},
 		Retention: &buildv1beta1.BuildRunRetention{
 			TTLAfterFailed: &metav1.Duration{
@@ -38,7 +50,19 @@ func TestBuildRunSpecFromFlags(t *testing.T) {
 				Duration: 30 * time.Minute,
 			},
 		},
-		NodeSelector:     map[string]string{"kubernetes.io/hostname": "worker-1"},
+		Volumes: []buildv1beta1.BuildVolume{{
+			Name: "cache",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "build-cache"},
+			},
+		}},
+		NodeSelector: map[string]string{"kubernetes.io/hostname": "worker-1"},
+		Tolerations: []corev1.Toleration{{
+			Key:      "dedicated",
+			Operator: corev1.TolerationOpEqual,
+			Value:    "builds",
+			Effect:   corev1.TaintEffectNoSchedule,
+		}},
 		SchedulerName:    ptr.To("dolphinscheduler"),
 		RuntimeClassName: ptr.To("kata"),
 	}
@@ -75,6 +99,17 @@ func TestBuildRunSpecFromFlags(t *testing.T) {
 		err = flags.Set(OutputCredentialsSecretFlag, *expected.Output.PushSecret)
 		g.Expect(err).To(o.BeNil())
 
+		for flag, value := range map[string]string{
+			OutputVulnScanFlag:           "true",
+			OutputVulnFailOnFindingFlag:  "true",
+			OutputVulnIgnoreIDFlag:       "CVE-2024-0001,CVE-2024-0002",
+			OutputVulnIgnoreSeverityFlag: "low",
+			OutputVulnIgnoreUnfixedFlag:  "true",
+			OutputTimestampFlag:          *expected.Output.Timestamp,
+		} {
+			g.Expect(flags.Set(flag, value)).To(o.Succeed())
+		}
+
 		g.Expect(*expected.Output).To(o.Equal(*spec.Output), "spec.output")
 	})
 
@@ -85,6 +120,20 @@ func TestBuildRunSpecFromFlags(t *testing.T) {
 		g.Expect(expected.NodeSelector).To(o.Equal(spec.NodeSelector), ".spec.nodeSelector")
 	})
 
+	t.Run(".spec.volumes", func(_ *testing.T) {
+		err := flags.Set(VolumeFlag, "cache=pvc:build-cache")
+		g.Expect(err).To(o.BeNil())
+
+		g.Expect(expected.Volumes).To(o.Equal(spec.Volumes), "spec.volumes")
+	})
+
+	t.Run(".spec.tolerations", func(_ *testing.T) {
+		err := flags.Set(TolerationFlag, "dedicated=builds:NoSchedule")
+		g.Expect(err).To(o.BeNil())
+
+		g.Expect(expected.Tolerations).To(o.Equal(spec.Tolerations), "spec.tolerations")
+	})
+
 	t.Run(".spec.schedulerName", func(_ *testing.T) {
 		err := flags.Set(SchedulerNameFlag, *expected.SchedulerName)
 		g.Expect(err).To(o.BeNil())
@@ -158,6 +207,29 @@ func TestSanitizeBuildRunSpec(t *testing.T) {
 			Retention: &buildv1beta1.BuildRunRetention{},
 		},
 		out: buildv1beta1.BuildRunSpec{},
+	}, {
+		name: "should keep an output with only the vulnerability scan",
+		in: buildv1beta1.BuildRunSpec{Output: &buildv1beta1.Image{
+			VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{Enabled: true, Ignore: &buildv1beta1.VulnerabilityIgnoreOptions{}},
+		}},
+		out: buildv1beta1.BuildRunSpec{Output: &buildv1beta1.Image{
+			VulnerabilityScan: &buildv1beta1.VulnerabilityScanOptions{Enabled: true},
+		}},
+	}, {
+		name: "should keep an output with only the timestamp",
+		in:   buildv1beta1.BuildRunSpec{Output: &buildv1beta1.Image{Timestamp: ptr.To(buildv1beta1.OutputImageZeroTimestamp)}},
+		out:  buildv1beta1.BuildRunSpec{Output: &buildv1beta1.Image{Timestamp: ptr.To(buildv1beta1.OutputImageZeroTimestamp)}},
+	}, {
+		name: "should clean-up an output with an empty timestamp",
+		in:   buildv1beta1.BuildRunSpec{Output: &buildv1beta1.Image{Timestamp: ptr.To("")}},
+		out:  buildv1beta1.BuildRunSpec{},
+	}, {
+		name: "should clean-up empty volumes and tolerations",
+		in: buildv1beta1.BuildRunSpec{
+			Volumes:     []buildv1beta1.BuildVolume{},
+			Tolerations: []corev1.Toleration{},
+		},
+		out: buildv1beta1.BuildRunSpec{},
 	}, {
 		name: "should clean-up runtime-class-name",
 		in:   buildv1beta1.BuildRunSpec{RuntimeClassName: ptr.To("")},
@@ -0,0 +1,90 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DryRunStrategy describes how a request is performed when the dry-run flag is informed.
+type DryRunStrategy string
+
+const (
+	// DryRunNone the request is sent to the cluster and persisted.
+	DryRunNone DryRunStrategy = "none"
+	// DryRunClient the object is only rendered locally, the cluster is not contacted.
+	DryRunClient DryRunStrategy = "client"
+	// DryRunServer the request is validated by the cluster, including admission webhooks, without
+	// persisting the object.
+	DryRunServer DryRunStrategy = "server"
+)
+
+// DryRun returns the value for the "dryRun" request option, only set for the server strategy.
+func (s DryRunStrategy) DryRun() []string {
+	if s == DryRunServer {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// Suffix returns the text appended to messages describing the action taken.
+func (s DryRunStrategy) Suffix() string {
+	switch s {
+	case DryRunClient:
+		return " (dry run)"
+	case DryRunServer:
+		return " (server dry run)"
+	default:
+		return ""
+	}
+}
+
+// DryRunValue implements pflag.Value interface, to represent the DryRunStrategy as a string
+// command-line flag.
+type DryRunValue struct {
+	strategyPtr *DryRunStrategy
+}
+
+// String shows the value as string.
+func (d *DryRunValue) String() string {
+	if d.strategyPtr == nil {
+		return ""
+	}
+	return string(*d.strategyPtr)
+}
+
+// Set makes sure the informed value is a known strategy.
+func (d *DryRunValue) Set(value string) error {
+	strategy := DryRunStrategy(value)
+	if strategy != DryRunNone && strategy != DryRunClient && strategy != DryRunServer {
+		return fmt.Errorf("'%s' is an invalid dry-run strategy, must be %q, %q or %q",
+			value, DryRunNone, DryRunClient, DryRunServer)
+	}
+	*d.strategyPtr = strategy
+	return nil
+}
+
+// Type analogous to the pflag "string".
+func (d *DryRunValue) Type() string {
+	return "string"
+}
+
+// NewDryRunValue creates a new instance of DryRunValue sharing an existing reference.
+func NewDryRunValue(strategyPtr *DryRunStrategy) *DryRunValue {
+	return &DryRunValue{strategyPtr: strategyPtr}
+}
+
+// DryRunFlags registers the dry-run flag, when informed without a value the client strategy is
+// assumed.
+func DryRunFlags(flags *pflag.FlagSet, strategy *DryRunStrategy) {
+	*strategy = DryRunNone
+	flags.Var(
+		NewDryRunValue(strategy),
+		DryRunFlag,
+		fmt.Sprintf("must be %q, %q or %q, with %q the object is only printed, with %q the request is sent to the cluster without persisting it",
+			DryRunNone, DryRunClient, DryRunServer, DryRunClient, DryRunServer),
+	)
+	flags.Lookup(DryRunFlag).NoOptDefVal = string(DryRunClient)
+}
@@ -0,0 +1,30 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDryRunValue(t *testing.T) {
+	g := o.NewWithT(t)
+
+	var strategy DryRunStrategy
+	cmd := &cobra.Command{}
+	DryRunFlags(cmd.Flags(), &strategy)
+	g.Expect(strategy).To(o.Equal(DryRunNone))
+	g.Expect(strategy.DryRun()).To(o.BeNil())
+
+	g.Expect(cmd.Flags().Parse([]string{"--dry-run"})).To(o.Succeed())
+	g.Expect(strategy).To(o.Equal(DryRunClient))
+	g.Expect(strategy.Suffix()).To(o.Equal(" (dry run)"))
+
+	g.Expect(cmd.Flags().Set(DryRunFlag, "server")).To(o.Succeed())
+	g.Expect(strategy).To(o.Equal(DryRunServer))
+	g.Expect(strategy.DryRun()).To(o.Equal([]string{metav1.DryRunAll}))
+
+	g.Expect(cmd.Flags().Set(DryRunFlag, "invalid")).ToNot(o.Succeed())
+}
@@ -9,17 +9,26 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 const (
+	// AllNamespacesFlag command-line flag.
+	AllNamespacesFlag = "all-namespaces"
 	// BuildrefNameFlag command-line flag.
 	BuildrefNameFlag = "buildref-name"
 	// BuilderImageFlag command-line flag.
 	BuilderImageFlag = "builder-image"
 	// DockerfileFlag command-line flag.
 	DockerfileFlag = "dockerfile"
+	// DryRunFlag command-line flag.
+	DryRunFlag = "dry-run"
 	// EnvFlag command-line flag.
 	EnvFlag = "env"
+	// EnvRemoveFlag command-line flag.
+	EnvRemoveFlag = "env-remove"
+	// FieldSelectorFlag command-line flag.
+	FieldSelectorFlag = "field-selector"
 	// SourceGitURLFlag command-line flag.
 	SourceGitURLFlag = "source-git-url"
 	// SourceURLFlag command-line flag.
@@ -52,10 +61,16 @@ const (
 	OutputImageFlag = "output-image"
 	// OutputInsecureFlag command-line flag.
 	OutputInsecureFlag = "output-insecure"
+	// OutputImagePushSecretFlag command-line flag.
+	OutputImagePushSecretFlag = "output-image-push-secret" // #nosec G101
 	// OutputCredentialsSecretFlag command-line flag.
 	OutputCredentialsSecretFlag = "output-credentials-secret" // #nosec G101
 	// ParamValueFlag command-line flag.
 	ParamValueFlag = "param-value"
+	// ParamValueRemoveFlag command-line flag.
+	ParamValueRemoveFlag = "param-value-remove"
+	// SelectorFlag command-line flag.
+	SelectorFlag = "selector"
 	// ServiceAccountNameFlag command-line flag.
 	ServiceAccountNameFlag = "sa-name"
 	// ServiceAccountGenerateFlag command-line flag.
@@ -76,10 +91,32 @@ const (
 	RetentionTTLAfterSucceededFlag = "retention-ttl-after-succeeded"
 	// NodeSelectorFlag command-line flag.
 	NodeSelectorFlag = "node-selector"
+	// NodeSelectorRemoveFlag command-line flag.
+	NodeSelectorRemoveFlag = "node-selector-remove"
 	// SchedulerNameFlag command-line flag.
 	SchedulerNameFlag = "scheduler-name"
 	// RuntimeClassNameFlag command-line flag.
 	RuntimeClassNameFlag = "runtime-class"
+	// TolerationFlag command-line flag.
+	TolerationFlag = "toleration"
+	// VolumeFlag command-line flag.
+	VolumeFlag = "volume"
+	// OutputTimestampFlag command-line flag.
+	OutputTimestampFlag = "output-timestamp"
+	// OutputVulnScanFlag command-line flag.
+	OutputVulnScanFlag = "output-vuln-scan"
+	// OutputVulnFailOnFindingFlag command-line flag.
+	OutputVulnFailOnFindingFlag = "output-vuln-fail-on-finding"
+	// OutputVulnIgnoreIDFlag command-line flag.
+	OutputVulnIgnoreIDFlag = "output-vuln-ignore-id"
+	// OutputVulnIgnoreSeverityFlag command-line flag.
+	OutputVulnIgnoreSeverityFlag = "output-vuln-ignore-severity"
+	// OutputVulnIgnoreUnfixedFlag command-line flag.
+	OutputVulnIgnoreUnfixedFlag = "output-vuln-ignore-unfixed"
+	// WaitFlag command-line flag.
+	WaitFlag = "wait"
+	// WaitTimeoutFlag command-line flag.
+	WaitTimeoutFlag = "wait-timeout"
 )
 
 // sourceFlags flags for ".spec.source"
@@ -211,9 +248,88 @@ func imageFlags(flags *pflag.FlagSet, prefix string, image *buildv1beta1.Image)
 			false,
 			"flag to indicate an insecure container registry",
 		)
+		flags.Var(
+			timestampFlag{ref: image.Timestamp},
+			OutputTimestampFlag,
+			fmt.Sprintf("output image timestamp, either %s, %s, %s, or the number of seconds since the UNIX epoch",
+				buildv1beta1.OutputImageZeroTimestamp,
+				buildv1beta1.OutputImageSourceTimestamp,
+				buildv1beta1.OutputImageBuildTimestamp,
+			),
+		)
 	}
 }
 
+// vulnerabilityScanFlags registers flags for the output image vulnerability scan options.
+func vulnerabilityScanFlags(flags *pflag.FlagSet, scan *buildv1beta1.VulnerabilityScanOptions) {
+	flags.BoolVar(
+		&scan.Enabled,
+		OutputVulnScanFlag,
+		false,
+		"scan the output image for vulnerabilities",
+	)
+	flags.BoolVar(
+		&scan.FailOnFinding,
+		OutputVulnFailOnFindingFlag,
+		false,
+		"fail the BuildRun when the vulnerability scan finds vulnerabilities",
+	)
+	flags.StringSliceVar(
+		&scan.Ignore.ID,
+		OutputVulnIgnoreIDFlag,
+		nil,
+		"vulnerability IDs to be ignored by the scan, e.g. CVE-2024-1234",
+	)
+	flags.Var(
+		ignoredSeverityFlag{ref: scan.Ignore.Severity},
+		OutputVulnIgnoreSeverityFlag,
+		fmt.Sprintf("ignore the vulnerabilities of the severity and lower, either %s, %s, or %s",
+			buildv1beta1.IgnoredLow, buildv1beta1.IgnoredMedium, buildv1beta1.IgnoredHigh),
+	)
+	flags.BoolVar(
+		scan.Ignore.Unfixed,
+		OutputVulnIgnoreUnfixedFlag,
+		false,
+		"ignore the vulnerabilities for which no fix exists",
+	)
+}
+
+// newVulnerabilityScanOptions returns the vulnerability scan options with empty inner data
+// structures, to be filled by the command-line flags.
+func newVulnerabilityScanOptions() *buildv1beta1.VulnerabilityScanOptions {
+	return &buildv1beta1.VulnerabilityScanOptions{
+		Ignore: &buildv1beta1.VulnerabilityIgnoreOptions{
+			Severity: ptr.To(buildv1beta1.IgnoredVulnerabilitySeverity("")),
+			Unfixed:  ptr.To(false),
+		},
+	}
+}
+
+// sanitizeVulnerabilityScan replaces the empty vulnerability scan options with nil.
+func sanitizeVulnerabilityScan(scan *buildv1beta1.VulnerabilityScanOptions) *buildv1beta1.VulnerabilityScanOptions {
+	if scan == nil {
+		return nil
+	}
+	if ignore := scan.Ignore; ignore != nil {
+		if len(ignore.ID) == 0 {
+			ignore.ID = nil
+		}
+		if ignore.Severity != nil && *ignore.Severity == "" {
+			ignore.Severity = nil
+		}
+		if ignore.Unfixed != nil && !*ignore.Unfixed {
+			ignore.Unfixed = nil
+		}
+		if ignore.ID == nil && ignore.Severity == nil && ignore.Unfixed == nil {
+			scan.Ignore = nil
+		}
+	}
+	if !scan.Enabled && !scan.FailOnFinding && scan.Ignore == nil {
+		return nil
+	}
+	return scan
+}
+
 // dockerfileFlags register dockerfile flag as an environment variable.
 func dockerfileFlags(flags *pflag.FlagSet, dockerfile *string) {
 	flags.StringVar(
@@ -289,7 +405,7 @@ func buildSchedulerNameFlag(flags *pflag.FlagSet, schedulerName *string) {
 	)
 }
 
-// buildRuntimeClassNameFlag registers flags for adding BuildRunSpec.RuntimeClassName
+// buildRuntimeClassNameFlag registers flags for adding BuildSpec.RuntimeClassName
 func buildRuntimeClassNameFlag(flags *pflag.FlagSet, runtimeClassName *string) {
 	flags.StringVar(
 		runtimeClassName,
@@ -299,6 +415,24 @@ func buildRuntimeClassNameFlag(flags *pflag.FlagSet, runtimeClassName *string) {
 	)
 }
 
+// buildTolerationsFlags registers flags for adding BuildSpec.Tolerations
+func buildTolerationsFlags(flags *pflag.FlagSet, tolerations *[]corev1.Toleration) {
+	flags.Var(
+		NewTolerationArrayValue(tolerations),
+		TolerationFlag,
+		"specify a toleration for the Pod in the key[=value]:NoSchedule format",
+	)
+}
+
+// buildVolumesFlags registers flags for overriding the strategy volumes.
+func buildVolumesFlags(flags *pflag.FlagSet, volumes *[]buildv1beta1.BuildVolume) {
+	flags.Var(
+		NewBuildVolumeArrayValue(volumes),
+		VolumeFlag,
+		"override a strategy volume, in the name=configmap:<name>, name=secret:<name>, name=pvc:<claim> or name=emptydir format",
+	)
+}
+
 // envFlags registers flags for adding corev1.EnvVars.
 func envFlags(flags *pflag.FlagSet, envs *[]corev1.EnvVar) {
 	flags.VarP(
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
 )
 
@@ -14,3 +16,36 @@ func FollowFlag(flags *pflag.FlagSet, follow *bool) {
 		"Start a build and watch its log until it completes or fails.",
 	)
 }
+
+// FollowOutput controls how the container log lines are shown while following a BuildRun.
+type FollowOutput struct {
+	Prefix  bool // prefix each line with the step name
+	NoColor bool // disable the colorized prefix, even on a terminal
+}
+
+// FollowOutputFlags registers the flags controlling the followed log lines.
+func FollowOutputFlags(flags *pflag.FlagSet, output *FollowOutput) {
+	flags.BoolVar(
+		&output.Prefix,
+		"prefix",
+		true,
+		"Prefix each followed log line with the step name",
+	)
+	flags.BoolVar(
+		&output.NoColor,
+		"no-color",
+		false,
+		"Disable the colorized step name prefix, colors are only employed on a terminal",
+	)
+}
+
+// PendingGracePeriodFlag registers the flag controlling for how long the followed pod may be
+// unschedulable or failing to pull images, with the informed default.
+func PendingGracePeriodFlag(flags *pflag.FlagSet, gracePeriod *time.Duration, defaultValue time.Duration) {
+	flags.DurationVar(
+		gracePeriod,
+		"pending-grace-period",
+		defaultValue,
+		"Fail when the pod is unschedulable or can't pull an image for longer than the duration, zero disables it",
+	)
+}
@@ -0,0 +1,45 @@
+package flags
+
+import (
+	"fmt"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// ignoredSeverityFlag serves as an adapter to make the Build output vulnerability scan ignored
+// severity to be used as a command-line flag (pflag.Value).
+type ignoredSeverityFlag struct {
+	ref *buildv1beta1.IgnoredVulnerabilitySeverity
+}
+
+// Set translates the provided input string into one of the supported severities, or fails with an
+// error in cases of an unsupported value
+func (i ignoredSeverityFlag) Set(val string) error {
+	var severity = buildv1beta1.IgnoredVulnerabilitySeverity(val)
+	switch severity {
+	case buildv1beta1.IgnoredLow, buildv1beta1.IgnoredMedium, buildv1beta1.IgnoredHigh:
+		*i.ref = severity
+		return nil
+
+	default:
+		return fmt.Errorf("supported values are %s, %s, or %s",
+			buildv1beta1.IgnoredLow,
+			buildv1beta1.IgnoredMedium,
+			buildv1beta1.IgnoredHigh,
+		)
+	}
+}
+
+// String returns a string representation of the ignored severity, empty when it is not set
+func (i ignoredSeverityFlag) String() string {
+	if i.ref == nil {
+		return ""
+	}
+
+	return string(*i.ref)
+}
+
+// Type returns the type string, which is printed in the usage help output
+func (i ignoredSeverityFlag) Type() string {
+	return "severity"
+}
@@ -0,0 +1,32 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+func TestIgnoredSeverity(t *testing.T) {
+	g := o.NewWithT(t)
+
+	// Check for type and defaults
+	g.Expect(ignoredSeverityFlag{}.Type()).To(o.Equal("severity"))
+	g.Expect(ignoredSeverityFlag{}.String()).To(o.Equal(""))
+
+	var obj buildv1beta1.IgnoredVulnerabilitySeverity
+	v := ignoredSeverityFlag{ref: &obj}
+
+	// Check the supported values
+	for _, severity := range []buildv1beta1.IgnoredVulnerabilitySeverity{
+		buildv1beta1.IgnoredLow,
+		buildv1beta1.IgnoredMedium,
+		buildv1beta1.IgnoredHigh,
+	} {
+		g.Expect(v.Set(string(severity))).Should(o.Succeed())
+		g.Expect(v.String()).To(o.Equal(string(severity)))
+	}
+
+	// Check that invalid values fail with the flag
+	g.Expect(v.Set("critical")).ToNot(o.Succeed())
+}
@@ -0,0 +1,55 @@
+package flags
+
+import (
+	"github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListFilter contains the command-line flags to narrow down the objects listed.
+type ListFilter struct {
+	LabelSelector string // label selector, like "-l key=value"
+	FieldSelector string // field selector, like "--field-selector metadata.name=value"
+	AllNamespaces bool   // list across all namespaces
+}
+
+// ListOptions returns the list options carrying the informed selectors.
+func (f *ListFilter) ListOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: f.LabelSelector,
+		FieldSelector: f.FieldSelector,
+	}
+}
+
+// Namespace returns the namespace to list objects from, when listing across all namespaces the
+// informed namespace is ignored.
+func (f *ListFilter) Namespace(namespace string) string {
+	if f.AllNamespaces {
+		return metav1.NamespaceAll
+	}
+	return namespace
+}
+
+// ListFilterFlags registers the selector and all-namespaces flags.
+func ListFilterFlags(flags *pflag.FlagSet, f *ListFilter) {
+	flags.StringVarP(
+		&f.LabelSelector,
+		SelectorFlag,
+		"l",
+		"",
+		"label selector to filter on, supports '=', '==', '!=', 'in' and 'notin' (e.g. -l key1=value1,key2=value2)",
+	)
+	flags.StringVar(
+		&f.FieldSelector,
+		FieldSelectorFlag,
+		"",
+		"field selector to filter on, supports '=', '==' and '!=' (e.g. --field-selector metadata.name=value)",
+	)
+	flags.BoolVarP(
+		&f.AllNamespaces,
+		AllNamespacesFlag,
+		"A",
+		false,
+		"list the objects across all namespaces, the namespace flag is ignored",
+	)
+}
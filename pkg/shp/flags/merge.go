@@ -0,0 +1,111 @@
+package flags
+
+import (
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/pflag"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MergeMap copies the src entries onto dst, creating it when needed.
+func MergeMap(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// UpsertEnv replaces the environment variable with the same name, or appends it.
+func UpsertEnv(envs *[]corev1.EnvVar, env corev1.EnvVar) {
+	for i := range *envs {
+		if (*envs)[i].Name == env.Name {
+			(*envs)[i] = env
+			return
+		}
+	}
+	*envs = append(*envs, env)
+}
+
+// UpsertParam replaces the parameter value with the same name, or appends it.
+func UpsertParam(paramValues *[]buildv1beta1.ParamValue, pv buildv1beta1.ParamValue) {
+	for i := range *paramValues {
+		if (*paramValues)[i].Name == pv.Name {
+			(*paramValues)[i] = pv
+			return
+		}
+	}
+	*paramValues = append(*paramValues, pv)
+}
+
+// UpsertVolume replaces the volume with the same name, or appends it.
+func UpsertVolume(volumes *[]buildv1beta1.BuildVolume, volume buildv1beta1.BuildVolume) {
+	for i := range *volumes {
+		if (*volumes)[i].Name == volume.Name {
+			(*volumes)[i] = volume
+			return
+		}
+	}
+	*volumes = append(*volumes, volume)
+}
+
+// UpsertToleration replaces the toleration with the same key and effect, or appends it.
+func UpsertToleration(tolerations *[]corev1.Toleration, toleration corev1.Toleration) {
+	for i := range *tolerations {
+		if (*tolerations)[i].Key == toleration.Key && (*tolerations)[i].Effect == toleration.Effect {
+			(*tolerations)[i] = toleration
+			return
+		}
+	}
+	*tolerations = append(*tolerations, toleration)
+}
+
+// MergeVulnerabilityScan copies onto dst the vulnerability scan options which have been explicitly
+// informed on the command-line, returning dst, created when needed. The src may have been sanitized,
+// a nil src or ignore options are taken as empty.
+func MergeVulnerabilityScan(
+	fs *pflag.FlagSet,
+	dst *buildv1beta1.VulnerabilityScanOptions,
+	src *buildv1beta1.VulnerabilityScanOptions,
+) *buildv1beta1.VulnerabilityScanOptions {
+	if !(fs.Changed(OutputVulnScanFlag) || fs.Changed(OutputVulnFailOnFindingFlag) ||
+		fs.Changed(OutputVulnIgnoreIDFlag) || fs.Changed(OutputVulnIgnoreSeverityFlag) ||
+		fs.Changed(OutputVulnIgnoreUnfixedFlag)) {
+		return dst
+	}
+	if src == nil {
+		src = &buildv1beta1.VulnerabilityScanOptions{}
+	}
+	if dst == nil {
+		dst = &buildv1beta1.VulnerabilityScanOptions{}
+	}
+	if fs.Changed(OutputVulnScanFlag) {
+		dst.Enabled = src.Enabled
+	}
+	if fs.Changed(OutputVulnFailOnFindingFlag) {
+		dst.FailOnFinding = src.FailOnFinding
+	}
+	if !(fs.Changed(OutputVulnIgnoreIDFlag) || fs.Changed(OutputVulnIgnoreSeverityFlag) ||
+		fs.Changed(OutputVulnIgnoreUnfixedFlag)) {
+		return sanitizeVulnerabilityScan(dst)
+	}
+	srcIgnore := src.Ignore
+	if srcIgnore == nil {
+		srcIgnore = &buildv1beta1.VulnerabilityIgnoreOptions{}
+	}
+	if dst.Ignore == nil {
+		dst.Ignore = &buildv1beta1.VulnerabilityIgnoreOptions{}
+	}
+	if fs.Changed(OutputVulnIgnoreIDFlag) {
+		dst.Ignore.ID = srcIgnore.ID
+	}
+	if fs.Changed(OutputVulnIgnoreSeverityFlag) {
+		dst.Ignore.Severity = srcIgnore.Severity
+	}
+	if fs.Changed(OutputVulnIgnoreUnfixedFlag) {
+		dst.Ignore.Unfixed = srcIgnore.Unfixed
+	}
+	return sanitizeVulnerabilityScan(dst)
+}
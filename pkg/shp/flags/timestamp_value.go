@@ -0,0 +1,50 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// timestampFlag serves as an adapter to make the Build output image timestamp to be used as a
+// command-line flag (pflag.Value).
+type timestampFlag struct {
+	ref *string
+}
+
+// Set validates the provided input string is either one of the supported timestamp settings, or a
+// number of seconds since the UNIX epoch
+func (t timestampFlag) Set(val string) error {
+	switch val {
+	case buildv1beta1.OutputImageZeroTimestamp,
+		buildv1beta1.OutputImageSourceTimestamp,
+		buildv1beta1.OutputImageBuildTimestamp:
+		*t.ref = val
+		return nil
+	}
+
+	if _, err := strconv.ParseInt(val, 10, 64); err != nil {
+		return fmt.Errorf("supported values are %s, %s, %s, or the number of seconds since the UNIX epoch",
+			buildv1beta1.OutputImageZeroTimestamp,
+			buildv1beta1.OutputImageSourceTimestamp,
+			buildv1beta1.OutputImageBuildTimestamp,
+		)
+	}
+	*t.ref = val
+	return nil
+}
+
+// String returns the timestamp setting, empty when it is not set
+func (t timestampFlag) String() string {
+	if t.ref == nil {
+		return ""
+	}
+
+	return *t.ref
+}
+
+// Type returns the type string, which is printed in the usage help output
+func (t timestampFlag) Type() string {
+	return "timestamp"
+}
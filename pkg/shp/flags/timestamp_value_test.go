@@ -0,0 +1,34 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+func TestOutputTimestamp(t *testing.T) {
+	g := o.NewWithT(t)
+
+	// Check for type and defaults
+	g.Expect(timestampFlag{}.Type()).To(o.Equal("timestamp"))
+	g.Expect(timestampFlag{}.String()).To(o.Equal(""))
+
+	var obj string
+	v := timestampFlag{ref: &obj}
+
+	// Check the supported values
+	for _, timestamp := range []string{
+		buildv1beta1.OutputImageZeroTimestamp,
+		buildv1beta1.OutputImageSourceTimestamp,
+		buildv1beta1.OutputImageBuildTimestamp,
+		"1691650396",
+	} {
+		g.Expect(v.Set(timestamp)).Should(o.Succeed())
+		g.Expect(v.String()).To(o.Equal(timestamp))
+	}
+
+	// Check that invalid values fail with the flag
+	g.Expect(v.Set("2023-08-10")).ToNot(o.Succeed())
+	g.Expect(v.Set("zero")).ToNot(o.Succeed())
+}
@@ -0,0 +1,95 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TolerationArrayValue implements pflag.Value interface, in order to store corev1.Toleration
+// entries used on Shipwright's BuildSpec and BuildRunSpec.
+type TolerationArrayValue struct {
+	tolerations *[]corev1.Toleration // pointer to the slice of Toleration
+}
+
+// String prints out the string representation of the slice of Toleration objects.
+func (t *TolerationArrayValue) String() string {
+	slice := []string{}
+	for _, toleration := range *t.tolerations {
+		slice = append(slice, FormatToleration(toleration))
+	}
+	csv, _ := writeAsCSV(slice)
+	return fmt.Sprintf("[%s]", csv)
+}
+
+// Set receives a toleration entry with the format "key[=value]:NoSchedule", without a value
+// the "Exists" operator is employed, and "Equal" otherwise.
+func (t *TolerationArrayValue) Set(value string) error {
+	toleration, err := parseToleration(value)
+	if err != nil {
+		return err
+	}
+	for _, existing := range *t.tolerations {
+		if existing.Key == toleration.Key && existing.Effect == toleration.Effect {
+			return fmt.Errorf("toleration for key '%s' and effect '%s' is already set",
+				toleration.Key, toleration.Effect)
+		}
+	}
+	*t.tolerations = append(*t.tolerations, toleration)
+	return nil
+}
+
+// Type analogous to the pflag "stringArray" type, where each flag entry will be translated to a
+// single array (slice) entry.
+func (t *TolerationArrayValue) Type() string {
+	return "stringArray"
+}
+
+// NewTolerationArrayValue instantiate a TolerationArrayValue sharing the Toleration pointer.
+func NewTolerationArrayValue(tolerations *[]corev1.Toleration) *TolerationArrayValue {
+	return &TolerationArrayValue{tolerations: tolerations}
+}
+
+// parseToleration parses the "key[=value]:NoSchedule" format into a Toleration, Shipwright only
+// accepts the "NoSchedule" effect, without toleration seconds.
+func parseToleration(value string) (corev1.Toleration, error) {
+	toleration := corev1.Toleration{}
+	invalidErr := fmt.Errorf("informed value '%s' is not in key[=value]:%s format", value, corev1.TaintEffectNoSchedule)
+
+	s := strings.Split(value, ":")
+	if len(s) != 2 {
+		return toleration, invalidErr
+	}
+
+	key, val, hasValue := strings.Cut(s[0], "=")
+	if key == "" {
+		return toleration, invalidErr
+	}
+	toleration.Key = key
+	toleration.Operator = corev1.TolerationOpExists
+	if hasValue {
+		toleration.Operator = corev1.TolerationOpEqual
+		toleration.Value = val
+	}
+
+	if effect := corev1.TaintEffect(s[1]); effect != corev1.TaintEffectNoSchedule {
+		return toleration, fmt.Errorf("informed effect '%s' is not supported, only %s is allowed", s[1],
+			corev1.TaintEffectNoSchedule)
+	}
+	toleration.Effect = corev1.TaintEffectNoSchedule
+	return toleration, nil
+}
+
+// FormatToleration renders the Toleration using the same format accepted by the flag.
+func FormatToleration(t corev1.Toleration) string {
+	s := t.Key
+	if t.Operator != corev1.TolerationOpExists {
+		s = fmt.Sprintf("%s=%s", s, t.Value)
+	}
+	s = fmt.Sprintf("%s:%s", s, t.Effect)
+	if t.TolerationSeconds != nil {
+		s = fmt.Sprintf("%s:%d", s, *t.TolerationSeconds)
+	}
+	return s
+}
@@ -0,0 +1,61 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTolerationArrayValue(t *testing.T) {
+	g := o.NewWithT(t)
+
+	tolerations := []corev1.Toleration{}
+	v := NewTolerationArrayValue(&tolerations)
+
+	flagName := "toleration"
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Var(v, flagName, "")
+
+	for _, value := range []string{
+		"dedicated=builds:NoSchedule",
+		"gpu:NoSchedule",
+		"node.kubernetes.io/unreachable:NoSchedule",
+	} {
+		g.Expect(flags.Set(flagName, value)).To(o.Succeed())
+	}
+
+	g.Expect(tolerations).To(o.Equal([]corev1.Toleration{{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "builds",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}, {
+		Key:      "gpu",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}, {
+		Key:      "node.kubernetes.io/unreachable",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}}))
+
+	g.Expect(v.String()).To(o.Equal(
+		"[dedicated=builds:NoSchedule,gpu:NoSchedule,node.kubernetes.io/unreachable:NoSchedule]"))
+
+	for _, value := range []string{
+		"dedicated=builds",
+		"=builds:NoSchedule",
+		"dedicated=builds:Never",
+		"gpu:PreferNoSchedule",
+		"gpu:NoExecute",
+		"dedicated=builds:NoSchedule:300",
+		"node.kubernetes.io/unreachable:NoExecute:300",
+		"dedicated=other:NoSchedule",
+	} {
+		g.Expect(flags.Set(flagName, value)).NotTo(o.Succeed(), value)
+	}
+	g.Expect(tolerations).To(o.HaveLen(3))
+}
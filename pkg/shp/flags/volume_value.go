@@ -0,0 +1,96 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// volume source types accepted by the volume flag.
+const (
+	volumeConfigMap = "configmap"
+	volumeSecret    = "secret"
+	volumePVC       = "pvc"
+	volumeEmptyDir  = "emptydir"
+)
+
+// BuildVolumeArrayValue implements pflag.Value interface, in order to store the BuildVolume entries
+// used on Shipwright's BuildSpec and BuildRunSpec to override the strategy volumes.
+type BuildVolumeArrayValue struct {
+	volumes *[]buildv1beta1.BuildVolume // pointer to the slice of BuildVolume
+}
+
+// String prints out the string representation of the slice of BuildVolume objects.
+func (b *BuildVolumeArrayValue) String() string {
+	slice := []string{}
+	for _, volume := range *b.volumes {
+		slice = append(slice, FormatBuildVolume(volume))
+	}
+	csv, _ := writeAsCSV(slice)
+	return fmt.Sprintf("[%s]", csv)
+}
+
+// Set receives a volume entry with the format "name=configmap:<name>", "name=secret:<name>",
+// "name=pvc:<claim>" or "name=emptydir".
+func (b *BuildVolumeArrayValue) Set(value string) error {
+	name, source, err := splitKeyValue(value)
+	if err != nil {
+		return err
+	}
+	for _, volume := range *b.volumes {
+		if volume.Name == name {
+			return fmt.Errorf("volume '%s' is already set", name)
+		}
+	}
+
+	volume := buildv1beta1.BuildVolume{Name: name}
+	kind, ref, _ := strings.Cut(source, ":")
+	switch {
+	case kind == volumeEmptyDir && ref == "":
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+	case kind == volumeConfigMap && ref != "":
+		volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ref},
+		}
+	case kind == volumeSecret && ref != "":
+		volume.Secret = &corev1.SecretVolumeSource{SecretName: ref}
+	case kind == volumePVC && ref != "":
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: ref}
+	default:
+		return fmt.Errorf("informed volume source '%s' is not supported, use %s:<name>, %s:<name>, %s:<claim> or %s",
+			source, volumeConfigMap, volumeSecret, volumePVC, volumeEmptyDir)
+	}
+	*b.volumes = append(*b.volumes, volume)
+	return nil
+}
+
+// Type analogous to the pflag "stringArray" type, where each flag entry will be translated to a
+// single array (slice) entry.
+func (b *BuildVolumeArrayValue) Type() string {
+	return "stringArray"
+}
+
+// NewBuildVolumeArrayValue instantiate a BuildVolumeArrayValue sharing the BuildVolume pointer.
+func NewBuildVolumeArrayValue(volumes *[]buildv1beta1.BuildVolume) *BuildVolumeArrayValue {
+	return &BuildVolumeArrayValue{volumes: volumes}
+}
+
+// FormatBuildVolume renders the BuildVolume using the same format accepted by the flag, other
+// volume sources are rendered only by name.
+func FormatBuildVolume(v buildv1beta1.BuildVolume) string {
+	switch {
+	case v.EmptyDir != nil:
+		return fmt.Sprintf("%s=%s", v.Name, volumeEmptyDir)
+	case v.ConfigMap != nil:
+		return fmt.Sprintf("%s=%s:%s", v.Name, volumeConfigMap, v.ConfigMap.Name)
+	case v.Secret != nil:
+		return fmt.Sprintf("%s=%s:%s", v.Name, volumeSecret, v.Secret.SecretName)
+	case v.PersistentVolumeClaim != nil:
+		return fmt.Sprintf("%s=%s:%s", v.Name, volumePVC, v.PersistentVolumeClaim.ClaimName)
+	default:
+		return v.Name
+	}
+}
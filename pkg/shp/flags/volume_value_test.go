@@ -0,0 +1,71 @@
+package flags
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/pflag"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBuildVolumeArrayValue(t *testing.T) {
+	g := o.NewWithT(t)
+
+	volumes := []buildv1beta1.BuildVolume{}
+	v := NewBuildVolumeArrayValue(&volumes)
+
+	flagName := "volume"
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Var(v, flagName, "")
+
+	for _, value := range []string{
+		"cache=pvc:build-cache",
+		"settings=configmap:maven-settings",
+		"credentials=secret:registry-auth",
+		"scratch=emptydir",
+	} {
+		g.Expect(flags.Set(flagName, value)).To(o.Succeed())
+	}
+
+	g.Expect(volumes).To(o.Equal([]buildv1beta1.BuildVolume{{
+		Name: "cache",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "build-cache"},
+		},
+	}, {
+		Name: "settings",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "maven-settings"},
+			},
+		},
+	}, {
+		Name: "credentials",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "registry-auth"},
+		},
+	}, {
+		Name: "scratch",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}}))
+
+	g.Expect(v.String()).To(o.Equal(
+		"[cache=pvc:build-cache,settings=configmap:maven-settings,credentials=secret:registry-auth,scratch=emptydir]"))
+
+	for _, value := range []string{
+		"cache",
+		"=emptydir",
+		"cache=emptydir",
+		"other=hostpath:/tmp",
+		"other=configmap",
+		"other=pvc:",
+		"other=emptydir:scratch",
+	} {
+		g.Expect(flags.Set(flagName, value)).NotTo(o.Succeed(), value)
+	}
+	g.Expect(volumes).To(o.HaveLen(4))
+}
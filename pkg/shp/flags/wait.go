@@ -0,0 +1,24 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// WaitFlags registers the flags to wait for the BuildRun completion, the timeout flag has a distinct
+// name since "timeout" is taken by the BuildRun timeout itself.
+func WaitFlags(flags *pflag.FlagSet, wait *bool, timeout *time.Duration) {
+	flags.BoolVar(
+		wait,
+		WaitFlag,
+		false,
+		"wait for the BuildRun to complete without streaming its logs, exits with non-zero status when it fails",
+	)
+	flags.DurationVar(
+		timeout,
+		WaitTimeoutFlag,
+		0,
+		"maximum time to wait for the BuildRun to complete, zero means no limit",
+	)
+}
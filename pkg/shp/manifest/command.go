@@ -0,0 +1,123 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const strategyManifestDesc = `
+The manifest is validated before it's submitted: every parameter referenced on the steps must be
+declared, array parameters must be used as a whole argument with "[*]", defaults must match the
+parameter type, and volume mounts must refer to declared volumes.`
+
+// StrategyKind describes the build strategy kind handled by the create and apply sub-commands.
+type StrategyKind struct {
+	Kind    string // kind name, i.e. "BuildStrategy"
+	Plural  string // kind plural, i.e. "BuildStrategies"
+	Command string // parent command name, i.e. "buildstrategy"
+	Scope   string // where the objects are created, i.e. " in the current namespace"
+}
+
+// StrategyCommand holds the flags shared by the create and apply sub-commands of the build
+// strategy kinds, reading the manifest and submitting each object.
+type StrategyCommand struct {
+	cmd             *cobra.Command
+	kind            StrategyKind
+	filename        string
+	serverSideApply bool
+	forceConflicts  bool
+}
+
+// NewStrategyCommand instantiates the create sub-command of the informed kind, or the apply
+// sub-command when serverSideApply is true.
+func NewStrategyCommand(kind StrategyKind, serverSideApply bool) *StrategyCommand {
+	s := &StrategyCommand{kind: kind, serverSideApply: serverSideApply}
+	if serverSideApply {
+		s.cmd = &cobra.Command{
+			Use:   "apply -f <filename>",
+			Short: fmt.Sprintf("Create or update a %s%s from a manifest", kind.Kind, kind.Scope),
+			Long: fmt.Sprintf(`
+Creates or updates, using server-side apply, one or more %s described on the
+informed YAML or JSON manifest. For example:
+
+	$ shp %s apply -f buildah.yaml
+`, kind.Plural, kind.Command) + strategyManifestDesc,
+			Args: cobra.NoArgs,
+		}
+		s.cmd.Flags().BoolVar(&s.forceConflicts, "force-conflicts", false,
+			"take ownership of fields managed by other clients on conflicts")
+	} else {
+		s.cmd = &cobra.Command{
+			Use:   "create -f <filename>",
+			Short: fmt.Sprintf("Create a %s%s from a manifest", kind.Kind, kind.Scope),
+			Long: fmt.Sprintf(`
+Creates one or more %s described on the informed YAML or JSON manifest. For
+example:
+
+	$ shp %s create -f buildah.yaml
+`, kind.Plural, kind.Command) + strategyManifestDesc,
+			Args: cobra.NoArgs,
+		}
+	}
+	s.cmd.Flags().StringVarP(&s.filename, "filename", "f", "",
+		fmt.Sprintf("file containing the %s, or \"-\" for standard input", kind.Kind))
+	return s
+}
+
+// Cmd returns cobra command object
+func (s *StrategyCommand) Cmd() *cobra.Command { return s.cmd }
+
+// Read reads and validates the objects on the informed manifest.
+func (s *StrategyCommand) Read(stdin io.Reader) ([]Object, error) {
+	if s.filename == "" {
+		return nil, errors.New("the manifest must be informed via --filename")
+	}
+	objects, err := Read([]string{s.filename}, false, stdin)
+	if err != nil {
+		return nil, err
+	}
+	if err = Validate(objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// Submit creates the informed object, or applies it using server-side apply, employing the
+// kind-specific client calls.
+func (s *StrategyCommand) Submit(
+	ctx context.Context,
+	out io.Writer,
+	obj runtime.Object,
+	name string,
+	create func(ctx context.Context) error,
+	patch func(ctx context.Context, data []byte, opts metav1.PatchOptions) error,
+) error {
+	if !s.serverSideApply {
+		if err := create(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s created '%s'\n", s.kind.Kind, name)
+		return nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if err := patch(ctx, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &s.forceConflicts,
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s applied '%s'\n", s.kind.Kind, name)
+	return nil
+}
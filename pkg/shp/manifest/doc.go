@@ -0,0 +1,3 @@
+// Package manifest reads Shipwright resources from YAML or JSON files, directories or standard
+// input, decoding them strictly against the v1beta1 API types.
+package manifest
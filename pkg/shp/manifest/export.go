@@ -0,0 +1,84 @@
+package manifest
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/shipwright-io/cli/pkg/shp/printer"
+)
+
+// clusterManagedFields metadata attributes set by the cluster, meaningless on another cluster.
+var clusterManagedFields = []string{
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+	"managedFields",
+	"selfLink",
+	"ownerReferences",
+	"namespace",
+}
+
+// lastAppliedAnnotation annotation recorded by "kubectl apply".
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Clean returns a portable representation of the object, without status, namespace and the
+// metadata attributes managed by the cluster, ready to be created or applied elsewhere.
+func Clean(obj runtime.Object) (*unstructured.Unstructured, error) {
+	converted, err := printer.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u, ok := converted.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unable to export %T", obj)
+	}
+
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, field := range clusterManagedFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(u.Object, "metadata", "annotations", lastAppliedAnnotation)
+	if len(u.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+	}
+	if len(u.GetLabels()) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "labels")
+	}
+	return u, nil
+}
+
+// WriteYAML writes the informed objects, cleaned, as a multi-document YAML stream. The header
+// lines are written as YAML comments on top of the stream.
+func WriteYAML(w io.Writer, header []string, objs ...runtime.Object) error {
+	for _, line := range header {
+		if _, err := fmt.Fprintf(w, "# %s\n", line); err != nil {
+			return err
+		}
+	}
+	for i, obj := range objs {
+		u, err := Clean(obj)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(u.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 || len(header) > 0 {
+			if _, err := fmt.Fprintln(w, "---"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// Stdin is the file name representing the standard input.
+	Stdin = "-"
+	// FieldManager identifies the CLI as the owner of the fields set by server-side apply.
+	FieldManager = "shp"
+)
+
+// extensions file extensions considered when reading a directory.
+var extensions = []string{".yaml", ".yml", ".json"}
+
+// scheme knows only about the Shipwright types, any other kind is rejected.
+var scheme = runtime.NewScheme()
+
+// decoder decodes objects strictly, unknown and duplicated fields are reported as errors.
+var decoder runtime.Decoder
+
+func init() {
+	utilruntime.Must(buildv1beta1.AddToScheme(scheme))
+	decoder = serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDeserializer()
+}
+
+// Object a Shipwright resource decoded from a manifest, alongside the location it was read from.
+type Object struct {
+	Source string         // file name where the object was found
+	Object runtime.Object // typed object, like *buildv1beta1.Build
+}
+
+// Kind returns the object's kind.
+func (o *Object) Kind() string {
+	return o.Object.GetObjectKind().GroupVersionKind().Kind
+}
+
+// Name returns the object's name, or its generate-name prefix when the name is not set.
+func (o *Object) Name() string {
+	m, err := meta.Accessor(o.Object)
+	if err != nil {
+		return ""
+	}
+	if m.GetName() == "" {
+		return m.GetGenerateName()
+	}
+	return m.GetName()
+}
+
+// Read reads all Shipwright objects from the informed file names, directories are traversed for
+// YAML and JSON files, recursively when requested, and "-" reads the standard input.
+func Read(filenames []string, recursive bool, stdin io.Reader) ([]Object, error) {
+	var objects []Object
+	for _, filename := range filenames {
+		if filename == Stdin {
+			objs, err := Decode(Stdin, stdin)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, objs...)
+			continue
+		}
+
+		files, err := expand(filename, recursive)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			objs, err := decodeFile(file)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, objs...)
+		}
+	}
+	if len(objects) == 0 {
+		return nil, errors.New("no objects found on informed files")
+	}
+	return objects, nil
+}
+
+// expand returns the files a file name refers to, when it's a directory the files with known
+// extensions are returned instead.
+func expand(filename string, recursive bool) ([]string, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{filename}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(filename, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != filename && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, ext := range extensions {
+			if strings.EqualFold(filepath.Ext(path), ext) {
+				files = append(files, path)
+				break
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// decodeFile reads and decodes all objects in the informed file.
+func decodeFile(filename string) ([]Object, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(filename, f)
+}
+
+// Decode decodes all YAML documents, or JSON objects, available on the reader. The source is only
+// employed to identify where the objects came from.
+func Decode(source string, r io.Reader) ([]Object, error) {
+	var objects []Object
+	d := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw runtime.RawExtension
+		if err := d.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+
+		obj, gvk, err := decoder.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(*gvk)
+
+		o := Object{Source: source, Object: obj}
+		if o.Name() == "" {
+			return nil, fmt.Errorf("%s: %s without name", source, gvk.Kind)
+		}
+		objects = append(objects, o)
+	}
+}
@@ -0,0 +1,116 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+const buildManifest = `
+apiVersion: shipwright.io/v1beta1
+kind: Build
+metadata:
+  name: sample-go
+spec:
+  source:
+    type: Git
+    git:
+      url: https://github.com/shipwright-io/sample-go
+  strategy:
+    kind: ClusterBuildStrategy
+    name: buildpacks-v3
+  output:
+    image: registry/sample-go:latest
+`
+
+const buildRunManifest = `
+apiVersion: shipwright.io/v1beta1
+kind: BuildRun
+metadata:
+  generateName: sample-go-
+spec:
+  build:
+    name: sample-go
+`
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		kinds   []string
+		wantErr string
+	}{{
+		name:  "multiple documents",
+		input: buildManifest + "---\n" + buildRunManifest + "---\n",
+		kinds: []string{"Build", "BuildRun"},
+	}, {
+		name:  "json",
+		input: `{"apiVersion":"shipwright.io/v1beta1","kind":"ClusterBuildStrategy","metadata":{"name":"buildah"}}`,
+		kinds: []string{"ClusterBuildStrategy"},
+	}, {
+		name:    "unknown field",
+		input:   buildManifest + "  unknown: field\n",
+		wantErr: `unknown field "spec.unknown"`,
+	}, {
+		name:    "unsupported kind",
+		input:   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+		wantErr: "no kind \"ConfigMap\" is registered",
+	}, {
+		name:    "without name",
+		input:   "apiVersion: shipwright.io/v1beta1\nkind: Build\nspec: {}\n",
+		wantErr: "Build without name",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+			objects, err := Decode("test.yaml", strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				g.Expect(err).To(o.HaveOccurred())
+				g.Expect(err.Error()).To(o.ContainSubstring(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(o.HaveOccurred())
+			kinds := []string{}
+			for _, obj := range objects {
+				kinds = append(kinds, obj.Kind())
+			}
+			g.Expect(kinds).To(o.Equal(tt.kinds))
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	g := o.NewWithT(t)
+
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	g.Expect(os.Mkdir(nested, 0o755)).To(o.Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "build.yaml"), []byte(buildManifest), 0o600)).To(o.Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("# readme"), 0o600)).To(o.Succeed())
+	g.Expect(os.WriteFile(filepath.Join(nested, "buildrun.yml"), []byte(buildRunManifest), 0o600)).To(o.Succeed())
+
+	objects, err := Read([]string{dir}, false, nil)
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(objects).To(o.HaveLen(1))
+	b, ok := objects[0].Object.(*buildv1beta1.Build)
+	g.Expect(ok).To(o.BeTrue())
+	g.Expect(b.Spec.Output.Image).To(o.Equal("registry/sample-go:latest"))
+
+	objects, err = Read([]string{dir}, true, nil)
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(objects).To(o.HaveLen(2))
+	g.Expect(objects[1].Name()).To(o.Equal("sample-go-"))
+
+	objects, err = Read([]string{Stdin}, false, strings.NewReader(buildManifest))
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(objects).To(o.HaveLen(1))
+	g.Expect(objects[0].Source).To(o.Equal(Stdin))
+
+	_, err = Read([]string{Stdin}, false, strings.NewReader(""))
+	g.Expect(err).To(o.HaveOccurred())
+}
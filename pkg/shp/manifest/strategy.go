@@ -0,0 +1,127 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// systemParameterPrefix prefix of the parameters provided by Shipwright itself, like
+// "shp-source-root", which are not declared on the strategy.
+const systemParameterPrefix = "shp-"
+
+// paramReference matches "$(params.name)" and "$(params.name[*])" references.
+var paramReference = regexp.MustCompile(`\$\(params\.([a-zA-Z0-9_.-]+?)(\[\*\])?\)`)
+
+// ValidateBuildStrategySpec makes sure the strategy parameters are consistently declared and used:
+// every parameter referenced on steps must be declared, array parameters must be referenced as a
+// whole argument using "[*]", defaults must match the parameter type, and volume mounts must refer
+// to declared volumes. All issues found are reported together.
+func ValidateBuildStrategySpec(spec *buildv1beta1.BuildStrategySpec) error {
+	var errs []error
+
+	params := map[string]buildv1beta1.ParameterType{}
+	for _, p := range spec.Parameters {
+		if _, found := params[p.Name]; found {
+			errs = append(errs, fmt.Errorf("parameter %q is declared more than once", p.Name))
+		}
+		paramType := p.Type
+		if paramType == "" {
+			paramType = buildv1beta1.ParameterTypeString
+		}
+		params[p.Name] = paramType
+
+		switch paramType {
+		case buildv1beta1.ParameterTypeString:
+			if p.Defaults != nil {
+				errs = append(errs, fmt.Errorf("parameter %q of type %q must use \"default\", not \"defaults\"", p.Name, paramType))
+			}
+		case buildv1beta1.ParameterTypeArray:
+			if p.Default != nil {
+				errs = append(errs, fmt.Errorf("parameter %q of type %q must use \"defaults\", not \"default\"", p.Name, paramType))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("parameter %q has unknown type %q", p.Name, p.Type))
+		}
+	}
+
+	volumes := map[string]bool{}
+	for _, v := range spec.Volumes {
+		if volumes[v.Name] {
+			errs = append(errs, fmt.Errorf("volume %q is declared more than once", v.Name))
+		}
+		volumes[v.Name] = true
+	}
+
+	for _, step := range spec.Steps {
+		// array parameters can only be expanded as a whole command or argument entry
+		for _, arg := range append(append([]string{}, step.Command...), step.Args...) {
+			errs = append(errs, validateReferences(step.Name, arg, params, true)...)
+		}
+		for _, env := range step.Env {
+			errs = append(errs, validateReferences(step.Name, env.Value, params, false)...)
+		}
+		errs = append(errs, validateReferences(step.Name, step.WorkingDir, params, false)...)
+		errs = append(errs, validateVolumeMounts(step.Name, step.VolumeMounts, volumes)...)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateReferences checks the parameter references found on the informed value.
+func validateReferences(step, value string, params map[string]buildv1beta1.ParameterType, allowArray bool) []error {
+	var errs []error
+	for _, match := range paramReference.FindAllStringSubmatch(value, -1) {
+		name, expanded := match[1], match[2] != ""
+		if strings.HasPrefix(name, systemParameterPrefix) {
+			continue
+		}
+		paramType, found := params[name]
+		switch {
+		case !found:
+			errs = append(errs, fmt.Errorf("step %q references undeclared parameter %q", step, name))
+		case paramType == buildv1beta1.ParameterTypeArray && !expanded:
+			errs = append(errs, fmt.Errorf("step %q references array parameter %q without \"[*]\"", step, name))
+		case paramType == buildv1beta1.ParameterTypeArray && (!allowArray || value != match[0]):
+			errs = append(errs, fmt.Errorf("step %q can only use array parameter %q as a whole command or argument", step, name))
+		case paramType == buildv1beta1.ParameterTypeString && expanded:
+			errs = append(errs, fmt.Errorf("step %q references string parameter %q with \"[*]\"", step, name))
+		}
+	}
+	return errs
+}
+
+// validateVolumeMounts checks the volume mounts refer to volumes declared on the strategy.
+func validateVolumeMounts(step string, mounts []corev1.VolumeMount, volumes map[string]bool) []error {
+	var errs []error
+	for _, m := range mounts {
+		if !volumes[m.Name] {
+			errs = append(errs, fmt.Errorf("step %q mounts undeclared volume %q", step, m.Name))
+		}
+	}
+	return errs
+}
+
+// Validate runs the client-side validations available for the informed objects, currently the
+// build strategies parameters and volumes.
+func Validate(objects []Object) error {
+	var errs []error
+	for _, o := range objects {
+		var err error
+		switch obj := o.Object.(type) {
+		case *buildv1beta1.BuildStrategy:
+			err = ValidateBuildStrategySpec(&obj.Spec)
+		case *buildv1beta1.ClusterBuildStrategy:
+			err = ValidateBuildStrategySpec(&obj.Spec)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s %q: %w", o.Source, o.Kind(), o.Name(), err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
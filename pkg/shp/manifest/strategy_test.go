@@ -0,0 +1,100 @@
+package manifest
+
+import (
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateBuildStrategySpec(t *testing.T) {
+	params := []buildv1beta1.Parameter{
+		{Name: "storage-driver", Default: ptr.To("vfs")},
+		{Name: "build-args", Type: buildv1beta1.ParameterTypeArray, Defaults: &[]string{}},
+	}
+	volumes := []buildv1beta1.BuildStrategyVolume{{
+		Name:         "cache",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+
+	tests := []struct {
+		name    string
+		spec    buildv1beta1.BuildStrategySpec
+		wantErr []string
+	}{{
+		name: "valid",
+		spec: buildv1beta1.BuildStrategySpec{
+			Parameters: params,
+			Volumes:    volumes,
+			Steps: []buildv1beta1.Step{{
+				Name:         "build",
+				Command:      []string{"buildah"},
+				Args:         []string{"--storage-driver=$(params.storage-driver)", "$(params.build-args[*])", "$(params.shp-output-image)"},
+				Env:          []corev1.EnvVar{{Name: "DRIVER", Value: "$(params.storage-driver)"}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/cache"}},
+			}},
+		},
+	}, {
+		name: "undeclared parameter and volume",
+		spec: buildv1beta1.BuildStrategySpec{
+			Parameters: params,
+			Steps: []buildv1beta1.Step{{
+				Name:         "build",
+				Args:         []string{"$(params.unknown)"},
+				VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/cache"}},
+			}},
+		},
+		wantErr: []string{
+			`step "build" references undeclared parameter "unknown"`,
+			`step "build" mounts undeclared volume "cache"`,
+		},
+	}, {
+		name: "array and string references",
+		spec: buildv1beta1.BuildStrategySpec{
+			Parameters: params,
+			Steps: []buildv1beta1.Step{{
+				Name: "build",
+				Args: []string{"$(params.build-args)", "--args=$(params.build-args[*])", "$(params.storage-driver[*])"},
+				Env:  []corev1.EnvVar{{Name: "ARGS", Value: "$(params.build-args[*])"}},
+			}},
+		},
+		wantErr: []string{
+			`step "build" references array parameter "build-args" without "[*]"`,
+			`step "build" can only use array parameter "build-args" as a whole command or argument`,
+			`step "build" references string parameter "storage-driver" with "[*]"`,
+		},
+	}, {
+		name: "inconsistent defaults",
+		spec: buildv1beta1.BuildStrategySpec{
+			Parameters: []buildv1beta1.Parameter{
+				{Name: "a", Defaults: &[]string{"x"}},
+				{Name: "b", Type: buildv1beta1.ParameterTypeArray, Default: ptr.To("x")},
+				{Name: "b", Type: "object"},
+			},
+		},
+		wantErr: []string{
+			`parameter "a" of type "string" must use "default", not "defaults"`,
+			`parameter "b" of type "array" must use "defaults", not "default"`,
+			`parameter "b" is declared more than once`,
+			`parameter "b" has unknown type "object"`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+			err := ValidateBuildStrategySpec(&tt.spec)
+			if len(tt.wantErr) == 0 {
+				g.Expect(err).ToNot(o.HaveOccurred())
+				return
+			}
+			g.Expect(err).To(o.HaveOccurred())
+			for _, expected := range tt.wantErr {
+				g.Expect(err.Error()).To(o.ContainSubstring(expected))
+			}
+		})
+	}
+}
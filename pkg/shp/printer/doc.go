@@ -0,0 +1,3 @@
+// Package printer contains the shared printing layer used by sub-commands to render Shipwright
+// resources in the machine-readable formats known from kubectl, like "-o json" or "-o jsonpath".
+package printer
@@ -0,0 +1,195 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/cmd/get"
+)
+
+const (
+	// OutputFlag command-line flag.
+	OutputFlag = "output"
+	// wideFormat is the human-readable table format with additional columns.
+	wideFormat = "wide"
+)
+
+// scheme knows about the Shipwright and core types printed by the CLI, it's employed to fill up the
+// type information missing on objects returned by the typed clients.
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(buildv1beta1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+}
+
+// PrintFlags composes the cli-runtime print flags with the kubectl custom-columns flags, offering
+// the same output formats as "kubectl get". The human-readable table, default and "wide", is
+// rendered by the sub-command itself.
+type PrintFlags struct {
+	JSONYamlPrintFlags   *genericclioptions.JSONYamlPrintFlags
+	NamePrintFlags       *genericclioptions.NamePrintFlags
+	TemplatePrinterFlags *genericclioptions.KubeTemplatePrintFlags
+	CustomColumnsFlags   *get.CustomColumnsPrintFlags
+
+	OutputFormat string // informed output format, empty means human-readable table
+}
+
+// AllowedFormats returns the list of supported output formats.
+func (f *PrintFlags) AllowedFormats() []string {
+	formats := []string{wideFormat}
+	formats = append(formats, f.JSONYamlPrintFlags.AllowedFormats()...)
+	formats = append(formats, f.NamePrintFlags.AllowedFormats()...)
+	formats = append(formats, f.TemplatePrinterFlags.AllowedFormats()...)
+	formats = append(formats, f.CustomColumnsFlags.AllowedFormats()...)
+	return formats
+}
+
+// AddFlags registers the output flag and the template related flags on the informed command.
+func (f *PrintFlags) AddFlags(cmd *cobra.Command) {
+	f.JSONYamlPrintFlags.AddFlags(cmd)
+	f.TemplatePrinterFlags.AddFlags(cmd)
+	cmd.Flags().StringVarP(
+		&f.OutputFormat,
+		OutputFlag,
+		"o",
+		f.OutputFormat,
+		fmt.Sprintf("Output format. One of: (%s).", strings.Join(f.AllowedFormats(), ", ")),
+	)
+}
+
+// IsHumanReadable returns true when the output should be rendered as a table by the sub-command.
+func (f *PrintFlags) IsHumanReadable() bool {
+	return f.OutputFormat == "" || f.OutputFormat == wideFormat
+}
+
+// IsWide returns true when the "wide" output format is informed.
+func (f *PrintFlags) IsWide() bool {
+	return f.OutputFormat == wideFormat
+}
+
+// ToPrinter returns the printer for the informed machine-readable output format, the noHeaders
+// argument is honored by the custom-columns printer.
+func (f *PrintFlags) ToPrinter(noHeaders bool) (printers.ResourcePrinter, error) {
+	if f.IsHumanReadable() {
+		return nil, fmt.Errorf("output format %q is rendered by the command itself", f.OutputFormat)
+	}
+
+	if p, err := f.JSONYamlPrintFlags.ToPrinter(f.OutputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+	if p, err := f.NamePrintFlags.ToPrinter(f.OutputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+	if p, err := f.TemplatePrinterFlags.ToPrinter(f.OutputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+	f.CustomColumnsFlags.NoHeaders = noHeaders
+	if p, err := f.CustomColumnsFlags.ToPrinter(f.OutputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+
+	return nil, genericclioptions.NoCompatiblePrinterError{
+		OutputFormat:   &f.OutputFormat,
+		AllowedFormats: f.AllowedFormats(),
+	}
+}
+
+// Validate makes sure the informed output format is supported. Like kubectl, when only the template
+// flag is informed the "go-template" format is assumed.
+func (f *PrintFlags) Validate() error {
+	templateArg := f.TemplatePrinterFlags.TemplateArgument
+	if f.OutputFormat == "" && templateArg != nil && *templateArg != "" {
+		f.OutputFormat = "go-template"
+	}
+	if f.IsHumanReadable() {
+		return nil
+	}
+	_, err := f.ToPrinter(false)
+	return err
+}
+
+// Print renders the object, or list of objects, using the informed output format.
+func (f *PrintFlags) Print(obj runtime.Object, noHeaders bool, w io.Writer) error {
+	p, err := f.ToPrinter(noHeaders)
+	if err != nil {
+		return err
+	}
+	u, err := ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	return p.PrintObj(u, w)
+}
+
+// setGroupVersionKind sets the first external group-version-kind found on the scheme.
+func setGroupVersionKind(obj runtime.Object) error {
+	if !obj.GetObjectKind().GroupVersionKind().Empty() {
+		return nil
+	}
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return fmt.Errorf("missing apiVersion or kind and cannot assign it: %w", err)
+	}
+	for _, gvk := range gvks {
+		if gvk.Kind == "" || gvk.Version == "" || gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		return nil
+	}
+	return fmt.Errorf("unable to find the kind of %T", obj)
+}
+
+// ToUnstructured converts the informed object into its unstructured representation, lists are
+// flattened into a generic "List" of items, the same way kubectl renders them.
+func ToUnstructured(obj runtime.Object) (runtime.Object, error) {
+	if !meta.IsListType(obj) {
+		if err := setGroupVersionKind(obj); err != nil {
+			return nil, err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		return &unstructured.Unstructured{Object: content}, nil
+	}
+
+	items, err := meta.ExtractList(obj)
+	if err != nil {
+		return nil, err
+	}
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	list.SetAPIVersion("v1")
+	list.SetKind("List")
+	for _, item := range items {
+		u, err := ToUnstructured(item)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, *u.(*unstructured.Unstructured))
+	}
+	return list, nil
+}
+
+// NewPrintFlags instantiate the PrintFlags with empty output format, which means human-readable
+// output rendered by the sub-command.
+func NewPrintFlags() *PrintFlags {
+	return &PrintFlags{
+		JSONYamlPrintFlags:   genericclioptions.NewJSONYamlPrintFlags(),
+		NamePrintFlags:       genericclioptions.NewNamePrintFlags(""),
+		TemplatePrinterFlags: genericclioptions.NewKubeTemplatePrintFlags(),
+		CustomColumnsFlags:   get.NewCustomColumnsPrintFlags(),
+	}
+}
@@ -0,0 +1,103 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	o "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrintFlags(t *testing.T) {
+	buildList := &buildv1beta1.BuildList{
+		Items: []buildv1beta1.Build{{
+			ObjectMeta: metav1.ObjectMeta{Name: "b1", Namespace: "ns"},
+			Spec:       buildv1beta1.BuildSpec{Output: buildv1beta1.Image{Image: "registry/b1"}},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: "b2", Namespace: "ns"},
+			Spec:       buildv1beta1.BuildSpec{Output: buildv1beta1.Image{Image: "registry/b2"}},
+		}},
+	}
+
+	testCases := []struct {
+		name      string
+		format    string
+		noHeaders bool
+		contains  []string
+		wantErr   bool
+	}{{
+		name:     "json",
+		format:   "json",
+		contains: []string{`"kind": "List"`, `"kind": "Build"`, `"apiVersion": "shipwright.io/v1beta1"`, `"name": "b2"`},
+	}, {
+		name:     "yaml",
+		format:   "yaml",
+		contains: []string{"kind: List", "kind: Build", "name: b1"},
+	}, {
+		name:     "name",
+		format:   "name",
+		contains: []string{"build.shipwright.io/b1\n", "build.shipwright.io/b2\n"},
+	}, {
+		name:     "jsonpath",
+		format:   "jsonpath={.items[*].spec.output.image}",
+		contains: []string{"registry/b1 registry/b2"},
+	}, {
+		name:     "go-template",
+		format:   "go-template={{range .items}}{{.metadata.name}};{{end}}",
+		contains: []string{"b1;b2;"},
+	}, {
+		name:     "custom-columns",
+		format:   "custom-columns=NAME:.metadata.name,IMAGE:.spec.output.image",
+		contains: []string{"NAME", "IMAGE", "registry/b1"},
+	}, {
+		name:      "custom-columns without headers",
+		format:    "custom-columns=NAME:.metadata.name",
+		noHeaders: true,
+		contains:  []string{"b1\nb2\n"},
+	}, {
+		name:    "unsupported format",
+		format:  "xml",
+		wantErr: true,
+	}}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			f := NewPrintFlags()
+			f.OutputFormat = tt.format
+			g.Expect(f.IsHumanReadable()).To(o.BeFalse())
+
+			if tt.wantErr {
+				g.Expect(f.Validate()).ToNot(o.Succeed())
+				return
+			}
+			g.Expect(f.Validate()).To(o.Succeed())
+
+			out := &bytes.Buffer{}
+			g.Expect(f.Print(buildList.DeepCopy(), tt.noHeaders, out)).To(o.Succeed())
+			for _, s := range tt.contains {
+				g.Expect(out.String()).To(o.ContainSubstring(s))
+			}
+			if tt.noHeaders {
+				g.Expect(out.String()).ToNot(o.ContainSubstring("NAME"))
+			}
+		})
+	}
+}
+
+func TestPrintFlagsHumanReadable(t *testing.T) {
+	g := o.NewWithT(t)
+
+	f := NewPrintFlags()
+	g.Expect(f.IsHumanReadable()).To(o.BeTrue())
+	g.Expect(f.IsWide()).To(o.BeFalse())
+	g.Expect(f.Validate()).To(o.Succeed())
+
+	f.OutputFormat = "wide"
+	g.Expect(f.IsHumanReadable()).To(o.BeTrue())
+	g.Expect(f.IsWide()).To(o.BeTrue())
+	g.Expect(f.Validate()).To(o.Succeed())
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 )
@@ -34,14 +36,34 @@ type PodWatcher struct {
 	watcher     watch.Interface // client watch instance
 	listOpts    metav1.ListOptions
 
+	eventsWatcher         watch.Interface    // namespace warning events watch instance, when event functions are informed
+	eventsListOpts        metav1.ListOptions // options employed to list and watch the warning events
+	eventsResourceVersion string             // last seen event resource version, where the events watch resumes from
+	eventsReconnectCh     <-chan time.Time   // fires when the events watch must be re-established
+	eventsBackoff         time.Duration      // time to wait before retrying the events watch, doubled on each failure
+
+	resourceVersion   string            // last seen pod resource version, where the watch resumes from
+	handledVersions   map[string]string // last handled resource version per pod, to skip replayed events
+	relist            bool              // the resource version is too old, pods must be listed again
+	reconnectInterval time.Duration     // time to wait before retrying to re-establish the watch
+	reconnectCh       <-chan time.Time  // fires when the watch must be re-established
+	recheckAt         time.Time         // when the scheduled re-check fires
+	recheckCh         <-chan time.Time  // fires when the pods must be checked again, without new events
+
 	noPodEventsYetFn []NoPodEventsYetFn
 	toPodFn          []TimeoutPodFn
 	skipPodFn        []SkipPodFn
 	onPodAddedFn     []OnPodEventFn
 	onPodModifiedFn  []OnPodEventFn
 	onPodDeletedFn   []OnPodEventFn
+	onEventFn        []OnEventFn
+	onRecheckFn      []RecheckFn
+	onEventsErrorFn  []EventsErrorFn
 }
 
+// maxEventsBackoff is the longest time to wait before retrying to establish the events watch.
+const maxEventsBackoff = time.Minute
+
 // SkipPodFn a given pod instance is informed and expects a boolean as return. When true is returned
 // this container state processing is skipped completely.
 type SkipPodFn func(pod *corev1.Pod) bool
@@ -49,6 +71,16 @@ type SkipPodFn func(pod *corev1.Pod) bool
 // OnPodEventFn when a pod is modified this method handles the event.
 type OnPodEventFn func(pod *corev1.Pod) error
 
+// OnEventFn when a Kubernetes warning event is recorded in the namespace this method handles it.
+type OnEventFn func(event *corev1.Event) error
+
+// RecheckFn when a re-check scheduled with ScheduleRecheck fires this method handles it.
+type RecheckFn func() error
+
+// EventsErrorFn when the warning events can't be watched, like when listing events is forbidden, this
+// method is informed of the error, the events watch is given up.
+type EventsErrorFn func(err error)
+
 // TimeoutPodFn when either the context or request timeout expires before the Pod finishes
 type TimeoutPodFn func(msg string)
 
@@ -80,6 +112,37 @@ func (p *PodWatcher) WithOnPodDeletedFn(fn OnPodEventFn) *PodWatcher {
 	return p
 }
 
+// WithOnEventFn sets the function executed when a Kubernetes warning event is recorded in the namespace,
+// the events are only watched when at least one function is informed.
+func (p *PodWatcher) WithOnEventFn(fn OnEventFn) *PodWatcher {
+	p.onEventFn = append(p.onEventFn, fn)
+	return p
+}
+
+// WithOnRecheckFn sets the function executed when a re-check scheduled with ScheduleRecheck fires.
+func (p *PodWatcher) WithOnRecheckFn(fn RecheckFn) *PodWatcher {
+	p.onRecheckFn = append(p.onRecheckFn, fn)
+	return p
+}
+
+// WithEventsErrorFn sets the function executed when the warning events watch is given up.
+func (p *PodWatcher) WithEventsErrorFn(fn EventsErrorFn) *PodWatcher {
+	p.onEventsErrorFn = append(p.onEventsErrorFn, fn)
+	return p
+}
+
+// ScheduleRecheck schedules the execution of the re-check functions after the informed delay, even
+// when no new events arrive. Only the earliest scheduled re-check is kept, so it must be called
+// from the functions executed by the event loop.
+func (p *PodWatcher) ScheduleRecheck(delay time.Duration) {
+	at := time.Now().Add(delay)
+	if p.recheckCh != nil && !at.Before(p.recheckAt) {
+		return
+	}
+	p.recheckAt = at
+	p.recheckCh = time.After(delay)
+}
+
 // WithTimeoutPodFn sets the function executed when the context or request timeout fires
 func (p *PodWatcher) WithTimeoutPodFn(fn TimeoutPodFn) *PodWatcher {
 	p.toPodFn = append(p.toPodFn, fn)
@@ -127,18 +190,207 @@ func (p *PodWatcher) Connect(listOpts metav1.ListOptions) error {
 		return err
 	}
 	p.watcher = w
+
+	if len(p.onEventFn) == 0 {
+		return nil
+	}
+	// the events watch is best effort, the pod watch alone is enough to follow the BuildRun
+	p.eventsListOpts = metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String(),
+	}
+	p.reconnectEvents()
 	return nil
 }
 
+// watcherChan returns the pod watch channel, nil while the watch is being re-established so it's
+// never selected.
+func (p *PodWatcher) watcherChan() <-chan watch.Event {
+	if p.watcher == nil {
+		return nil
+	}
+	return p.watcher.ResultChan()
+}
+
+// scheduleReconnect stops the current pod watch, and schedules its re-establishment.
+func (p *PodWatcher) scheduleReconnect(relist bool, delay time.Duration) {
+	if p.watcher != nil {
+		p.watcher.Stop()
+		p.watcher = nil
+	}
+	p.relist = p.relist || relist
+	p.reconnectCh = time.After(delay)
+}
+
+// reconnect re-establishes the pod watch from the last seen resource version, when the resource
+// version is too old ("410 Gone") the pods are listed again, and returned so the changes missed
+// while the watch was down are handled. On error the reconnection is scheduled again.
+func (p *PodWatcher) reconnect() []corev1.Pod {
+	p.reconnectCh = nil
+	if p.isStopped() {
+		return nil
+	}
+	var pods []corev1.Pod
+	if p.relist || p.resourceVersion == "" {
+		podList, err := p.clientset.CoreV1().Pods(p.ns).List(p.ctx, p.listOpts)
+		if err != nil {
+			p.scheduleReconnect(false, p.reconnectInterval)
+			return nil
+		}
+		pods = podList.Items
+		p.resourceVersion = podList.GetResourceVersion()
+		p.relist = false
+	}
+
+	listOpts := p.listOpts
+	listOpts.ResourceVersion = p.resourceVersion
+	w, err := p.clientset.CoreV1().Pods(p.ns).Watch(p.ctx, listOpts)
+	if err != nil {
+		p.scheduleReconnect(kerrors.IsResourceExpired(err) || kerrors.IsGone(err), p.reconnectInterval)
+		return pods
+	}
+	p.watcher = w
+	return pods
+}
+
+// isHandled returns true when the informed pod version has already been handled, which happens when
+// events are replayed after the watch is re-established.
+func (p *PodWatcher) isHandled(pod *corev1.Pod) bool {
+	version := pod.GetResourceVersion()
+	if version == "" {
+		return false
+	}
+	key := string(pod.GetUID()) + "/" + pod.GetName()
+	if p.handledVersions[key] == version {
+		return true
+	}
+	p.handledVersions[key] = version
+	p.resourceVersion = version
+	return false
+}
+
+// processPod applies the skip functions, and the event functions when the pod is not skipped.
+func (p *PodWatcher) processPod(pod *corev1.Pod, event watch.Event) error {
+	if p.isHandled(pod) {
+		return nil
+	}
+	for _, fn := range p.skipPodFn {
+		if fn(pod) {
+			return nil
+		}
+	}
+	return p.handleEvent(pod, event)
+}
+
+// eventsChan returns the events watch channel, nil when events are not watched, or while the watch
+// is being re-established, so it's never selected.
+func (p *PodWatcher) eventsChan() <-chan watch.Event {
+	if p.eventsWatcher == nil {
+		return nil
+	}
+	return p.eventsWatcher.ResultChan()
+}
+
+// scheduleEventsReconnect stops the current events watch, and schedules its re-establishment.
+// When the resource version is too old the events are listed again to find the current one.
+func (p *PodWatcher) scheduleEventsReconnect(relist bool, delay time.Duration) {
+	if p.eventsWatcher != nil {
+		p.eventsWatcher.Stop()
+		p.eventsWatcher = nil
+	}
+	if relist {
+		p.eventsResourceVersion = ""
+	}
+	p.eventsReconnectCh = time.After(delay)
+}
+
+// eventsFailed handles the events watch errors. When the events can't be accessed the watch is given
+// up, otherwise it's re-established with an exponential backoff.
+func (p *PodWatcher) eventsFailed(err error) {
+	if kerrors.IsForbidden(err) || kerrors.IsUnauthorized(err) {
+		if p.eventsWatcher != nil {
+			p.eventsWatcher.Stop()
+			p.eventsWatcher = nil
+		}
+		for _, fn := range p.onEventsErrorFn {
+			fn(err)
+		}
+		return
+	}
+	if p.eventsBackoff == 0 {
+		p.eventsBackoff = p.reconnectInterval
+	}
+	p.scheduleEventsReconnect(kerrors.IsResourceExpired(err) || kerrors.IsGone(err), p.eventsBackoff)
+	p.eventsBackoff *= 2
+	if p.eventsBackoff > maxEventsBackoff {
+		p.eventsBackoff = maxEventsBackoff
+	}
+}
+
+// reconnectEvents establishes the warning events watch from the last seen resource version. Without
+// one, a single event is listed to find the current resource version, so the events recorded before
+// the watch started are not replayed. On error the reconnection is scheduled again, with backoff.
+func (p *PodWatcher) reconnectEvents() {
+	p.eventsReconnectCh = nil
+	if p.isStopped() {
+		return
+	}
+	if p.eventsResourceVersion == "" {
+		listOpts := p.eventsListOpts
+		listOpts.Limit = 1
+		eventList, err := p.clientset.CoreV1().Events(p.ns).List(p.ctx, listOpts)
+		if err != nil {
+			p.eventsFailed(err)
+			return
+		}
+		p.eventsResourceVersion = eventList.GetResourceVersion()
+	}
+
+	listOpts := p.eventsListOpts
+	listOpts.ResourceVersion = p.eventsResourceVersion
+	w, err := p.clientset.CoreV1().Events(p.ns).Watch(p.ctx, listOpts)
+	if err != nil {
+		p.eventsFailed(err)
+		return
+	}
+	p.eventsWatcher = w
+	p.eventsBackoff = 0
+}
+
+// stopWatchers stops the pod and events watch instances.
+func (p *PodWatcher) stopWatchers() {
+	if p.watcher != nil {
+		p.watcher.Stop()
+	}
+	if p.eventsWatcher != nil {
+		p.eventsWatcher.Stop()
+	}
+}
+
 // WaitForCompletion is the second of two methods called by Start, and it runs the event loop based on the watch instantiated (by Connect) against informed pod. In case of errors
 // the loop is interrupted.  Separating out WaitForCompletion from Start helps deal with the fake k8s clients, which are used by the unit tests,
 // and the capabilities of their Watch implementation.
 func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
+	// the timeout applies to the whole event loop, it must not be restarted by every event handled
+	timeoutCh := time.After(p.to)
 	for {
 		select {
 		// handling the regular pod modification events, which should trigger calling event functions
 		// accordinly
-		case event := <-p.watcher.ResultChan():
+		case event, ok := <-p.watcherChan():
+			// the API server closes watches on timeouts, or load balancers reset idle connections,
+			// the watch is then re-established from the last seen resource version
+			if !ok {
+				if p.isStopped() {
+					return nil, nil
+				}
+				p.scheduleReconnect(false, 0)
+				continue
+			}
+			if event.Type == watch.Error {
+				err := kerrors.FromObject(event.Object)
+				p.scheduleReconnect(kerrors.IsResourceExpired(err) || kerrors.IsGone(err), 0)
+				continue
+			}
 			if event.Object == nil {
 				continue
 			}
@@ -146,26 +398,67 @@ func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
 			if !ok {
 				continue
 			}
+			if err := p.processPod(pod, event); err != nil {
+				return pod, err
+			}
 
-			if len(p.skipPodFn) > 0 {
-				skip := false
-				for _, fn := range p.skipPodFn {
-					if fn(pod) {
-						skip = true
-						break
-					}
+		// re-establishing the pod watch, handling the pods listed again when the resource version
+		// was too old, since their changes may have been missed
+		case <-p.reconnectCh:
+			pods := p.reconnect()
+			for i := range pods {
+				pod := &pods[i]
+				if err := p.processPod(pod, watch.Event{Type: watch.Modified, Object: pod}); err != nil {
+					return pod, err
+				}
+			}
+		// re-establishing the events watch, closed by the API server or expired
+		case <-p.eventsReconnectCh:
+			p.reconnectEvents()
+		// handling the re-check scheduled by the event functions, like when a pod may be stuck for
+		// longer than allowed and no further events arrive
+		case <-p.recheckCh:
+			p.recheckCh = nil
+			for _, fn := range p.onRecheckFn {
+				if err := fn(); err != nil {
+					p.stopWatchers()
+					return nil, err
 				}
-				if skip {
-					continue
+			}
+		// handling the Kubernetes warning events recorded in the namespace, like scheduling or image
+		// pull failures, which are not reflected on the pod phase
+		case event, ok := <-p.eventsChan():
+			if !ok {
+				if p.isStopped() {
+					return nil, nil
 				}
+				p.scheduleEventsReconnect(false, 0)
+				continue
 			}
-			if err := p.handleEvent(pod, event); err != nil {
-				return pod, err
+			if event.Type == watch.Error {
+				p.eventsFailed(kerrors.FromObject(event.Object))
+				continue
+			}
+			k8sEvent, ok := event.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			if version := k8sEvent.GetResourceVersion(); version != "" {
+				p.eventsResourceVersion = version
+			}
+			if event.Type == watch.Deleted {
+				continue
+			}
+			for _, fn := range p.onEventFn {
+				if err := fn(k8sEvent); err != nil {
+					p.stopWatchers()
+					return nil, err
+				}
 			}
 		// watching over global context, when done is informed on the context it needs to reflect on
 		// the event loop as well.
 		case <-p.ctx.Done():
-			p.watcher.Stop()
+			p.stopWatchers()
 			for _, fn := range p.toPodFn {
 				fn(ContextTimeoutMessage)
 			}
@@ -173,8 +466,8 @@ func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
 
 		// handle k8s --request-timeout setting, converted to time.Duration, that is passed down to PodWatcher;
 		// if we have exceeded it, we exit
-		case <-time.After(p.to):
-			p.watcher.Stop()
+		case <-timeoutCh:
+			p.stopWatchers()
 			for _, fn := range p.toPodFn {
 				fn(RequestTimeoutMessage)
 			}
@@ -198,7 +491,7 @@ func (p *PodWatcher) WaitForCompletion() (*corev1.Pod, error) {
 
 		// watching over stop channel to stop the event loop on demand.
 		case <-p.stopCh:
-			p.watcher.Stop()
+			p.stopWatchers()
 			return nil, nil
 		}
 	}
@@ -226,6 +519,13 @@ func (p *PodWatcher) Stop() {
 	}
 }
 
+// isStopped returns true when the event loop has been stopped.
+func (p *PodWatcher) isStopped() bool {
+	p.stopLock.Lock()
+	defer p.stopLock.Unlock()
+	return p.stopped
+}
+
 // NewPodWatcher instantiate PodWatcher event-loop.
 func NewPodWatcher(
 	ctx context.Context,
@@ -234,5 +534,6 @@ func NewPodWatcher(
 	ns string,
 ) (*PodWatcher, error) {
 	// TODO don't think the have not received events yet ticker needs to be tunable, but leaving a TODO for now while we get feedback
-	return &PodWatcher{ctx: ctx, to: timeout, ns: ns, clientset: clientset, eventTicker: time.NewTicker(1 * time.Second), stopCh: make(chan bool), stopLock: sync.Mutex{}}, nil
+	return &PodWatcher{ctx: ctx, to: timeout, ns: ns, clientset: clientset, eventTicker: time.NewTicker(1 * time.Second), stopCh: make(chan bool), stopLock: sync.Mutex{},
+		handledVersions: map[string]string{}, reconnectInterval: time.Second}, nil
 }
@@ -2,11 +2,14 @@ package reactor
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
 	"time"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	fakekubetesting "k8s.io/client-go/testing"
 
 	o "github.com/onsi/gomega"
@@ -34,6 +37,29 @@ func Test_PodWatcher_RequestTimeout(t *testing.T) {
 	g.Expect(called).To(o.BeTrue())
 }
 
+func Test_PodWatcher_RequestTimeoutWithoutPodEvents(t *testing.T) {
+	g := o.NewWithT(t)
+
+	clientset := fake.NewSimpleClientset()
+
+	// longer than the interval of the no pod events ticker, which must not restart the timeout
+	pw, err := NewPodWatcher(context.TODO(), 1500*time.Millisecond, clientset, metav1.NamespaceDefault)
+	g.Expect(err).To(o.BeNil())
+	noPodEventsYet := 0
+	pw.WithNoPodEventsYetFn(func(_ *corev1.PodList) {
+		noPodEventsYet++
+	})
+	msg := ""
+	pw.WithTimeoutPodFn(func(m string) {
+		msg = m
+	})
+
+	_, err = pw.Start(metav1.ListOptions{})
+	g.Expect(err).ToNot(o.HaveOccurred())
+	g.Expect(msg).To(o.Equal(RequestTimeoutMessage))
+	g.Expect(noPodEventsYet).To(o.BeNumerically(">=", 1))
+}
+
 func Test_PodWatcher_ContextTimeout(t *testing.T) {
 	g := o.NewWithT(t)
 	ctx := context.TODO()
@@ -246,3 +272,229 @@ func validateEventChannelData(got, expected, verb string, ok bool, t *testing.T)
 		t.Fatalf("test channel %s value was %s instead of %s", verb, got, expected)
 	}
 }
+
+func Test_PodWatcher_OnEventFn(t *testing.T) {
+	g := o.NewWithT(t)
+	ctx := context.TODO()
+
+	clientset := fake.NewSimpleClientset()
+
+	pw, err := NewPodWatcher(ctx, math.MaxInt64, clientset, metav1.NamespaceDefault)
+	g.Expect(err).To(o.BeNil())
+
+	pw.WithOnEventFn(func(event *corev1.Event) error {
+		return errors.New(event.Reason)
+	})
+
+	g.Expect(pw.Connect(metav1.ListOptions{})).To(o.Succeed())
+	g.Expect(pw.eventsWatcher).NotTo(o.BeNil())
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "event"},
+		Type:       corev1.EventTypeWarning,
+		Reason:     "FailedScheduling",
+	}
+	_, err = clientset.CoreV1().Events(metav1.NamespaceDefault).Create(ctx, event, metav1.CreateOptions{})
+	g.Expect(err).To(o.BeNil())
+
+	_, err = pw.WaitForCompletion()
+	g.Expect(err).To(o.MatchError("FailedScheduling"))
+}
+
+func Test_PodWatcher_Reconnect(t *testing.T) {
+	newPod := func(resourceVersion string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       metav1.NamespaceDefault,
+			Name:            "pod",
+			UID:             "uid",
+			ResourceVersion: resourceVersion,
+		}}
+	}
+	// newClosedWatcher returns a watcher which sends the pods informed and is then closed by the
+	// API server
+	newClosedWatcher := func(pods ...*corev1.Pod) watch.Interface {
+		w := watch.NewFakeWithChanSize(len(pods), false)
+		for _, pod := range pods {
+			w.Modify(pod)
+		}
+		w.Stop()
+		return w
+	}
+
+	tests := []struct {
+		name     string
+		watches  []func() (watch.Interface, error)
+		listed   []corev1.Pod
+		versions []string // resource versions expected on each watch request
+		handled  []string // pod resource versions expected to be handled
+	}{{
+		name: "resumes from the last seen resource version",
+		watches: []func() (watch.Interface, error){
+			func() (watch.Interface, error) { return newClosedWatcher(newPod("1")), nil },
+			func() (watch.Interface, error) { return newClosedWatcher(newPod("1"), newPod("2")), nil },
+		},
+		versions: []string{"", "1"},
+		handled:  []string{"1", "2"},
+	}, {
+		name: "relists when the resource version is too old",
+		watches: []func() (watch.Interface, error){
+			func() (watch.Interface, error) { return newClosedWatcher(newPod("1")), nil },
+			func() (watch.Interface, error) { return nil, kerrors.NewResourceExpired("too old resource version") },
+			func() (watch.Interface, error) { return newClosedWatcher(newPod("3"), newPod("4")), nil },
+		},
+		listed:   []corev1.Pod{*newPod("3")},
+		versions: []string{"", "1", "5"},
+		handled:  []string{"1", "3", "4"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			clientset := fake.NewSimpleClientset()
+			versions := []string{}
+			clientset.PrependWatchReactor("pods", func(action fakekubetesting.Action) (bool, watch.Interface, error) {
+				restrictions := action.(fakekubetesting.WatchAction).GetWatchRestrictions()
+				versions = append(versions, restrictions.ResourceVersion)
+				if len(versions) > len(tt.watches) {
+					return true, watch.NewFake(), nil
+				}
+				w, err := tt.watches[len(versions)-1]()
+				return true, w, err
+			})
+			clientset.PrependReactor("list", "pods", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+				return true, &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: "5"}, Items: tt.listed}, nil
+			})
+
+			pw, err := NewPodWatcher(context.TODO(), math.MaxInt64, clientset, metav1.NamespaceDefault)
+			g.Expect(err).To(o.BeNil())
+			pw.reconnectInterval = time.Millisecond
+
+			handled := []string{}
+			pw.WithOnPodModifiedFn(func(pod *corev1.Pod) error {
+				handled = append(handled, pod.GetResourceVersion())
+				if pod.GetResourceVersion() == tt.handled[len(tt.handled)-1] {
+					pw.Stop()
+				}
+				return nil
+			})
+
+			_, err = pw.Start(metav1.ListOptions{})
+			g.Expect(err).To(o.BeNil())
+			g.Expect(handled).To(o.Equal(tt.handled))
+			g.Expect(versions).To(o.Equal(tt.versions))
+		})
+	}
+}
+
+func Test_PodWatcher_EventsReconnect(t *testing.T) {
+	g := o.NewWithT(t)
+
+	newEvent := func(resourceVersion, reason string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: reason, ResourceVersion: resourceVersion},
+			Type:       corev1.EventTypeWarning,
+			Reason:     reason,
+		}
+	}
+
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("list", "events", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+		return true, &corev1.EventList{ListMeta: metav1.ListMeta{ResourceVersion: "7"}, Items: []corev1.Event{*newEvent("6", "Old")}}, nil
+	})
+	versions := []string{}
+	selectors := []string{}
+	clientset.PrependWatchReactor("events", func(action fakekubetesting.Action) (bool, watch.Interface, error) {
+		restrictions := action.(fakekubetesting.WatchAction).GetWatchRestrictions()
+		versions = append(versions, restrictions.ResourceVersion)
+		selectors = append(selectors, restrictions.Fields.String())
+		w := watch.NewFakeWithChanSize(1, false)
+		if len(versions) == 1 {
+			// the first watch is closed by the API server
+			w.Add(newEvent("8", "FailedMount"))
+			w.Stop()
+			return true, w, nil
+		}
+		w.Add(newEvent("9", "FailedScheduling"))
+		return true, w, nil
+	})
+
+	pw, err := NewPodWatcher(context.TODO(), math.MaxInt64, clientset, metav1.NamespaceDefault)
+	g.Expect(err).To(o.BeNil())
+
+	handled := []string{}
+	pw.WithOnEventFn(func(event *corev1.Event) error {
+		handled = append(handled, event.Reason)
+		if event.Reason == "FailedScheduling" {
+			return errors.New(event.Reason)
+		}
+		return nil
+	})
+
+	_, err = pw.Start(metav1.ListOptions{})
+	g.Expect(err).To(o.MatchError("FailedScheduling"))
+	g.Expect(handled).To(o.Equal([]string{"FailedMount", "FailedScheduling"}))
+	g.Expect(versions).To(o.Equal([]string{"7", "8"}))
+	g.Expect(selectors).To(o.Equal([]string{"type=Warning", "type=Warning"}))
+}
+
+func Test_PodWatcher_EventsErrors(t *testing.T) {
+	eventsGR := corev1.Resource("events")
+
+	tests := []struct {
+		name       string
+		listErrors []error
+		verify     func(g *o.WithT, pw *PodWatcher, reported []error)
+	}{{
+		name:       "forbidden events are given up",
+		listErrors: []error{kerrors.NewForbidden(eventsGR, "", errors.New("rbac"))},
+		verify: func(g *o.WithT, pw *PodWatcher, reported []error) {
+			g.Expect(pw.eventsWatcher).To(o.BeNil())
+			g.Expect(pw.eventsReconnectCh).To(o.BeNil())
+			g.Expect(reported).To(o.HaveLen(1))
+			g.Expect(kerrors.IsForbidden(reported[0])).To(o.BeTrue())
+		},
+	}, {
+		name: "other errors are retried with backoff",
+		listErrors: []error{
+			kerrors.NewInternalError(errors.New("etcd")),
+			kerrors.NewServiceUnavailable("overloaded"),
+		},
+		verify: func(g *o.WithT, pw *PodWatcher, reported []error) {
+			g.Expect(pw.eventsReconnectCh).NotTo(o.BeNil())
+			g.Expect(pw.eventsBackoff).To(o.Equal(2 * time.Millisecond))
+			pw.reconnectEvents()
+			g.Expect(pw.eventsBackoff).To(o.Equal(4 * time.Millisecond))
+			pw.reconnectEvents()
+			g.Expect(pw.eventsWatcher).NotTo(o.BeNil())
+			g.Expect(pw.eventsBackoff).To(o.BeZero())
+			g.Expect(reported).To(o.BeEmpty())
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			clientset := fake.NewSimpleClientset()
+			calls := 0
+			clientset.PrependReactor("list", "events", func(_ fakekubetesting.Action) (bool, kruntime.Object, error) {
+				calls++
+				if calls <= len(tt.listErrors) {
+					return true, nil, tt.listErrors[calls-1]
+				}
+				return true, &corev1.EventList{ListMeta: metav1.ListMeta{ResourceVersion: "7"}}, nil
+			})
+
+			pw, err := NewPodWatcher(context.TODO(), math.MaxInt64, clientset, metav1.NamespaceDefault)
+			g.Expect(err).To(o.BeNil())
+			pw.reconnectInterval = time.Millisecond
+			pw.WithOnEventFn(func(_ *corev1.Event) error { return nil })
+			reported := []error{}
+			pw.WithEventsErrorFn(func(err error) { reported = append(reported, err) })
+
+			g.Expect(pw.Connect(metav1.ListOptions{})).To(o.Succeed())
+			tt.verify(g, pw, reported)
+		})
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -22,6 +25,10 @@ type Tail struct {
 	stopLock  sync.Mutex
 	stopped   bool
 
+	logOptions corev1.PodLogOptions // base options for the log requests
+	prefix     bool                 // prefix each line with the step name
+	color      bool                 // colorize the prefix, using a stable color per step
+
 	stdout io.Writer
 	stderr io.Writer
 }
@@ -36,42 +43,226 @@ func (t *Tail) SetStderr(w io.Writer) {
 	t.stderr = w
 }
 
+// SetLogOptions set the options employed on the log requests, like the amount of lines or the
+// timestamps, the container and follow attributes are always overwritten.
+func (t *Tail) SetLogOptions(opts corev1.PodLogOptions) {
+	t.logOptions = opts
+}
+
+// SetPrefix enables or disables the step name prefix on each log line, enabled by default.
+func (t *Tail) SetPrefix(prefix bool) {
+	t.prefix = prefix
+}
+
+// SetColor enables or disables the colorized step name prefix, disabled by default.
+func (t *Tail) SetColor(color bool) {
+	t.color = color
+}
+
+// stepColors ANSI colors employed on the step name prefix.
+var stepColors = []int{32, 33, 34, 35, 36, 92, 93, 94, 95, 96}
+
+// linePrefix returns the prefix for the log lines of the informed container, the step name is
+// employed, so the "step-" prefix is stripped from the container name.
+func (t *Tail) linePrefix(container string) string {
+	if !t.prefix {
+		return ""
+	}
+	step := strings.TrimPrefix(container, "step-")
+	if !t.color {
+		return fmt.Sprintf("[%s] ", step)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(step))
+	return fmt.Sprintf("\x1b[%dm[%s]\x1b[0m ", stepColors[h.Sum32()%uint32(len(stepColors))], step)
+}
+
 // Start start streaming logs for informed target.
 func (t *Tail) Start(ns, podName, container string) {
+	t.StartWithName(ns, podName, container, container)
+}
+
+// StartWithName start streaming logs for informed target, the log lines are prefixed with the
+// informed name instead of the container name, i.e. "task/step" for pods of a PipelineRun.
+func (t *Tail) StartWithName(ns, podName, container, name string) {
+	go t.stream(ns, podName, container, t.linePrefix(name))
 	go func() {
-		podClient := t.clientset.CoreV1().Pods(ns)
-		stream, err := podClient.GetLogs(podName, &corev1.PodLogOptions{
-			Follow:    true,
-			Container: container,
-		}).Stream(t.ctx)
-		if err != nil {
-			fmt.Fprintln(t.stderr, err)
+		<-t.ctx.Done()
+		t.Stop()
+	}()
+}
+
+// resumeInterval time to wait before resuming a log stream which has been cut.
+var resumeInterval = time.Second
+
+// stream streams the container logs. When the stream is cut while the container is still running,
+// i.e. by the API server or a load balancer, it's started again from the time of the last line
+// shown, skipping the lines already shown.
+func (t *Tail) stream(ns, podName, container, prefix string) {
+	opts := t.logOptions
+	opts.Follow = true
+	opts.Container = container
+	// the timestamps are always requested, so the stream can be resumed from the last line shown,
+	// the bytes limit is then enforced here since the timestamps would count against it
+	opts.Timestamps = true
+	opts.LimitBytes = nil
+
+	last := &lastLine{}
+	for {
+		err := t.streamOnce(ns, podName, &opts, prefix, last)
+		if t.isStopped() || t.limitReached(last) {
 			return
 		}
-		defer func() {
-			if err := stream.Close(); err != nil {
-				fmt.Fprintf(t.stderr, "Failed to close stream: %v", err)
+		if last.time.IsZero() || !t.isRunning(ns, podName, container) {
+			if err != nil {
+				fmt.Fprintln(t.stderr, err)
 			}
-		}()
+			return
+		}
+		opts.TailLines = nil
+		opts.SinceSeconds = nil
+		opts.SinceTime = &metav1.Time{Time: last.time}
+		select {
+		case <-t.stopCh:
+			return
+		case <-time.After(resumeInterval):
+		}
+	}
+}
 
-		go func() {
-			<-t.stopCh
+// streamOnce requests the container logs with the informed options, and writes the lines not shown
+// yet until the stream ends, the bytes limit is reached or the tail is stopped.
+func (t *Tail) streamOnce(ns, podName string, opts *corev1.PodLogOptions, prefix string, last *lastLine) error {
+	podClient := t.clientset.CoreV1().Pods(ns)
+	stream, err := podClient.GetLogs(podName, opts).Stream(t.ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			fmt.Fprintf(t.stderr, "Failed to close stream: %v", err)
+		}
+	}()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-t.stopCh:
 			if err := stream.Close(); err != nil {
 				fmt.Fprintf(t.stderr, "Failed to close stream: %v", err)
 			}
-		}()
-
-		containerName := strings.TrimPrefix(container, "step-")
-		sc := bufio.NewScanner(stream)
-		for sc.Scan() {
-			// #nosec G705: this is intentially printing everything that the BuildRun container logs
-			fmt.Fprintf(t.stdout, "[%s] %s\n", containerName, sc.Text())
+		case <-done:
 		}
 	}()
-	go func() {
-		<-t.ctx.Done()
-		t.Stop()
-	}()
+
+	// lines are read as a whole regardless of their length
+	r := bufio.NewReader(stream)
+	last.seen = 0
+	for {
+		line, err := r.ReadString('\n')
+		if line != "" && !t.show(strings.TrimSuffix(line, "\n"), prefix, last) {
+			return nil
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading logs of pod %q: %w", podName, err)
+		}
+	}
+}
+
+// show writes the informed log line, unless it has already been shown by a previous stream. When a
+// bytes limit is requested the line is truncated at it, false is returned once it's reached.
+func (t *Tail) show(line, prefix string, last *lastLine) bool {
+	text, ok := last.filter(line, t.logOptions.Timestamps)
+	if !ok {
+		return true
+	}
+	if limit := t.logOptions.LimitBytes; limit != nil {
+		// the line break counts against the limit, as it does on the API server
+		if remaining := *limit - last.bytes; int64(len(text)) >= remaining {
+			text = text[:remaining]
+		}
+		last.bytes += int64(len(text)) + 1
+	}
+	// #nosec G705: this is intentially printing everything that the BuildRun container logs
+	fmt.Fprintf(t.stdout, "%s%s\n", prefix, text)
+	return !t.limitReached(last)
+}
+
+// limitReached returns true when the requested bytes limit has been shown.
+func (t *Tail) limitReached(last *lastLine) bool {
+	return t.logOptions.LimitBytes != nil && last.bytes >= *t.logOptions.LimitBytes
+}
+
+// lastLine the time of the last log line shown, and the amount of lines shown with that time. The
+// bytes shown are kept across the resumed streams, so the requested limit applies to all of them.
+type lastLine struct {
+	time  time.Time
+	count int
+	seen  int   // lines of the current stream with the time of the last line shown
+	bytes int64 // bytes shown, without the prefix
+}
+
+// filter returns the text to show for the informed log line, without the timestamp unless requested,
+// and false when the line has already been shown by a previous stream.
+func (l *lastLine) filter(line string, timestamps bool) (string, bool) {
+	ts, text, ok := splitTimestamp(line)
+	if !ok {
+		return line, true
+	}
+	switch {
+	case ts.Before(l.time):
+		return "", false
+	case ts.Equal(l.time):
+		l.seen++
+		if l.seen <= l.count {
+			return "", false
+		}
+		l.count++
+	default:
+		l.time, l.count, l.seen = ts, 1, 1
+	}
+	if timestamps {
+		return line, true
+	}
+	return text, true
+}
+
+// splitTimestamp splits the timestamp added by the kubelet from the log line.
+func splitTimestamp(line string) (time.Time, string, bool) {
+	value, rest, found := strings.Cut(line, " ")
+	if !found {
+		value, rest = line, ""
+	}
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return ts, rest, true
+}
+
+// isRunning returns true when the informed container of the pod is running.
+func (t *Tail) isRunning(ns, podName, container string) bool {
+	pod, err := t.clientset.CoreV1().Pods(ns).Get(t.ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.Name == container {
+			return status.State.Running != nil
+		}
+	}
+	return false
+}
+
+// isStopped returns true when the log streaming has been stopped.
+func (t *Tail) isStopped() bool {
+	t.stopLock.Lock()
+	defer t.stopLock.Unlock()
+	return t.stopped
 }
 
 // Stop closes stop channel to stop log streaming.
@@ -93,6 +284,7 @@ func NewTail(ctx context.Context, clientset kubernetes.Interface) *Tail {
 		clientset: clientset,
 		stopCh:    make(chan bool, 1),
 		stopLock:  sync.Mutex{},
+		prefix:    true,
 		stdout:    os.Stdout,
 		stderr:    os.Stderr,
 	}
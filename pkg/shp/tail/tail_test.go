@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 )
 
 func Test_Tail(t *testing.T) {
@@ -59,3 +60,124 @@ func Test_Tail(t *testing.T) {
 	g.Expect(err).To(o.BeNil())
 	g.Expect(stderrNumBytes).To(o.Equal(int64(0)))
 }
+
+func Test_TailLinePrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    bool
+		color     bool
+		container string
+		expected  string
+	}{{
+		name:      "step name",
+		prefix:    true,
+		container: "step-build-and-push",
+		expected:  "[build-and-push] ",
+	}, {
+		name:      "container name without step prefix",
+		prefix:    true,
+		container: "prepare",
+		expected:  "[prepare] ",
+	}, {
+		name:      "pipeline task and step name",
+		prefix:    true,
+		container: "build/build-and-push",
+		expected:  "[build/build-and-push] ",
+	}, {
+		name:      "colorized",
+		prefix:    true,
+		color:     true,
+		container: "step-build-and-push",
+		expected:  "\x1b[32m[build-and-push]\x1b[0m ",
+	}, {
+		name:      "no prefix",
+		prefix:    false,
+		color:     true,
+		container: "step-build-and-push",
+		expected:  "",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := o.NewWithT(t)
+
+			logTail := NewTail(context.TODO(), fake.NewSimpleClientset())
+			logTail.SetPrefix(tt.prefix)
+			logTail.SetColor(tt.color)
+			g.Expect(logTail.linePrefix(tt.container)).To(o.Equal(tt.expected))
+		})
+	}
+}
+
+func Test_TailResumeFilter(t *testing.T) {
+	g := o.NewWithT(t)
+
+	last := &lastLine{}
+	show := func(timestamps bool, lines ...string) []string {
+		last.seen = 0
+		shown := []string{}
+		for _, line := range lines {
+			if text, ok := last.filter(line, timestamps); ok {
+				shown = append(shown, text)
+			}
+		}
+		return shown
+	}
+
+	g.Expect(show(false,
+		"2024-05-01T10:00:00.100000000Z first",
+		"2024-05-01T10:00:01.200000000Z second",
+		"2024-05-01T10:00:01.200000000Z third",
+	)).To(o.Equal([]string{"first", "second", "third"}))
+
+	// the resumed stream starts at the second of the last line shown, replaying some of the lines
+	g.Expect(show(true,
+		"2024-05-01T10:00:01.000000000Z before",
+		"2024-05-01T10:00:01.200000000Z second",
+		"2024-05-01T10:00:01.200000000Z third",
+		"2024-05-01T10:00:01.200000000Z fourth",
+		"2024-05-01T10:00:02.000000000Z fifth",
+	)).To(o.Equal([]string{
+		"2024-05-01T10:00:01.200000000Z fourth",
+		"2024-05-01T10:00:02.000000000Z fifth",
+	}))
+
+	// lines without timestamp are always shown
+	g.Expect(show(false, "fake logs")).To(o.Equal([]string{"fake logs"}))
+}
+
+func Test_TailLimitBytes(t *testing.T) {
+	g := o.NewWithT(t)
+
+	logTail := NewTail(context.TODO(), fake.NewSimpleClientset())
+	var stdout bytes.Buffer
+	logTail.SetStdout(&stdout)
+	logTail.SetLogOptions(corev1.PodLogOptions{LimitBytes: ptr.To[int64](16)})
+
+	last := &lastLine{}
+	show := func(lines ...string) bool {
+		last.seen = 0
+		for _, line := range lines {
+			if !logTail.show(line, "[step] ", last) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// the timestamps don't count against the limit, only the text shown
+	g.Expect(show(
+		"2024-05-01T10:00:00.100000000Z first",
+		"2024-05-01T10:00:01.200000000Z second",
+	)).To(o.BeTrue())
+	g.Expect(last.bytes).To(o.Equal(int64(13)))
+
+	// the resumed stream replays the last line, the limit applies across the streams
+	g.Expect(show(
+		"2024-05-01T10:00:01.200000000Z second",
+		"2024-05-01T10:00:02.000000000Z third",
+		"2024-05-01T10:00:03.000000000Z fourth",
+	)).To(o.BeFalse())
+	g.Expect(stdout.String()).To(o.Equal("[step] first\n[step] second\n[step] thi\n"))
+	g.Expect(logTail.limitReached(last)).To(o.BeTrue())
+}
@@ -0,0 +1,26 @@
+package shputil
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// YAMLDiff renders both objects as YAML and returns the unified diff between them, an empty string
+// means the objects are equivalent.
+func YAMLDiff(name string, original, modified interface{}) (string, error) {
+	a, err := yaml.Marshal(original)
+	if err != nil {
+		return "", err
+	}
+	b, err := yaml.Marshal(modified)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(a)),
+		B:        difflib.SplitLines(string(b)),
+		FromFile: name + " (current)",
+		ToFile:   name + " (updated)",
+		Context:  3,
+	})
+}
@@ -11,9 +11,19 @@ import (
 
 // GetPodLogs returns log output of the k8s container provided by pod and name
 func GetPodLogs(ctx context.Context, client kubernetes.Interface, pod corev1.Pod, container string) (string, error) {
-	podLogOpts := corev1.PodLogOptions{
-		Container: container,
-	}
+	return GetPodLogsWithOptions(ctx, client, pod, container, corev1.PodLogOptions{})
+}
+
+// GetPodLogsWithOptions returns log output of the k8s container provided by pod and name, using the
+// informed options to narrow down the log lines, or to include timestamps.
+func GetPodLogsWithOptions(
+	ctx context.Context,
+	client kubernetes.Interface,
+	pod corev1.Pod,
+	container string,
+	podLogOpts corev1.PodLogOptions,
+) (string, error) {
+	podLogOpts.Container = container
 	req := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &podLogOpts)
 	podLogs, err := req.Stream(ctx)
 	if err != nil {
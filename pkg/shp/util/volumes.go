@@ -0,0 +1,98 @@
+package shputil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	buildclientset "github.com/shipwright-io/build/pkg/client/clientset/versioned"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidateVolumes makes sure the informed volumes override the volumes declared as overridable by
+// the strategy. The validation is skipped when the strategy can't be retrieved, leaving it for the
+// build controller.
+func ValidateVolumes(
+	ctx context.Context,
+	clientset buildclientset.Interface,
+	namespace string,
+	strategy buildv1beta1.Strategy,
+	volumes []buildv1beta1.BuildVolume,
+) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	var strategyVolumes []buildv1beta1.BuildStrategyVolume
+	kind := buildv1beta1.NamespacedBuildStrategyKind
+	if strategy.Kind != nil {
+		kind = *strategy.Kind
+	}
+	switch kind {
+	case buildv1beta1.ClusterBuildStrategyKind:
+		cbs, err := clientset.ShipwrightV1beta1().ClusterBuildStrategies().
+			Get(ctx, strategy.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		strategyVolumes = cbs.GetVolumes()
+	default:
+		bs, err := clientset.ShipwrightV1beta1().BuildStrategies(namespace).
+			Get(ctx, strategy.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		strategyVolumes = bs.GetVolumes()
+	}
+
+	overridable := map[string]bool{}
+	for _, v := range strategyVolumes {
+		if v.Overridable != nil && *v.Overridable {
+			overridable[v.Name] = true
+		}
+	}
+	for _, v := range volumes {
+		if overridable[v.Name] {
+			continue
+		}
+		if len(overridable) == 0 {
+			return fmt.Errorf("volume %q can't be overridden, %s %q has no overridable volumes",
+				v.Name, kind, strategy.Name)
+		}
+		names := make([]string, 0, len(overridable))
+		for name := range overridable {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("volume %q can't be overridden, valid volume names for %s %q are: %s",
+			v.Name, kind, strategy.Name, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// ValidateBuildRunVolumes validates the BuildRun volumes against the strategy of the embedded
+// BuildSpec, or the strategy of the referenced Build when it can be retrieved.
+func ValidateBuildRunVolumes(
+	ctx context.Context,
+	clientset buildclientset.Interface,
+	namespace string,
+	spec *buildv1beta1.BuildRunSpec,
+) error {
+	if len(spec.Volumes) == 0 {
+		return nil
+	}
+	if spec.Build.Spec != nil {
+		return ValidateVolumes(ctx, clientset, namespace, spec.Build.Spec.Strategy, spec.Volumes)
+	}
+	if spec.Build.Name == nil {
+		return nil
+	}
+	b, err := clientset.ShipwrightV1beta1().Builds(namespace).Get(ctx, *spec.Build.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	return ValidateVolumes(ctx, clientset, namespace, b.Spec.Strategy, spec.Volumes)
+}